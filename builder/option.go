@@ -0,0 +1,197 @@
+package builder
+
+import (
+	"time"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ParamOption func(*tektonv1.ParamSpec)
+
+func newParamSpec(name string, opts []ParamOption) tektonv1.ParamSpec {
+	param := tektonv1.ParamSpec{Name: name, Type: tektonv1.ParamTypeString}
+	for _, opt := range opts {
+		opt(&param)
+	}
+	return param
+}
+
+func Default(value string) ParamOption {
+	return func(p *tektonv1.ParamSpec) {
+		p.Type = tektonv1.ParamTypeString
+		p.Default = tektonv1.NewStructuredValues(value)
+	}
+}
+
+func DefaultArray(values ...string) ParamOption {
+	return func(p *tektonv1.ParamSpec) {
+		p.Type = tektonv1.ParamTypeArray
+		p.Default = &tektonv1.ParamValue{Type: tektonv1.ParamTypeArray, ArrayVal: values}
+	}
+}
+
+func DefaultObject(values map[string]string) ParamOption {
+	return func(p *tektonv1.ParamSpec) {
+		p.Type = tektonv1.ParamTypeObject
+		p.Default = tektonv1.NewObject(values)
+		p.Properties = make(map[string]tektonv1.PropertySpec, len(values))
+		for key := range values {
+			p.Properties[key] = tektonv1.PropertySpec{Type: tektonv1.ParamTypeString}
+		}
+	}
+}
+
+// ArrayParam declares an array param without a default.
+func ArrayParam() ParamOption {
+	return func(p *tektonv1.ParamSpec) {
+		p.Type = tektonv1.ParamTypeArray
+	}
+}
+
+// ObjectParam declares an object param with string properties keys, without a default.
+func ObjectParam(keys ...string) ParamOption {
+	return func(p *tektonv1.ParamSpec) {
+		p.Type = tektonv1.ParamTypeObject
+		p.Properties = make(map[string]tektonv1.PropertySpec, len(keys))
+		for _, key := range keys {
+			p.Properties[key] = tektonv1.PropertySpec{Type: tektonv1.ParamTypeString}
+		}
+	}
+}
+
+func Enum(values ...string) ParamOption {
+	return func(p *tektonv1.ParamSpec) {
+		p.Enum = values
+	}
+}
+
+func ParamDescription(description string) ParamOption {
+	return func(p *tektonv1.ParamSpec) {
+		p.Description = description
+	}
+}
+
+type StepOption func(*tektonv1.Step)
+
+func Script(script string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.Script = script
+	}
+}
+
+func Command(command ...string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.Command = command
+	}
+}
+
+func Args(args ...string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.Args = args
+	}
+}
+
+func WorkingDir(dir string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.WorkingDir = dir
+	}
+}
+
+func Env(name, value string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.Env = append(s.Env, corev1.EnvVar{Name: name, Value: value})
+	}
+}
+
+func VolumeMount(name, mountPath string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.VolumeMounts = append(s.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: mountPath})
+	}
+}
+
+func Resources(resources corev1.ResourceRequirements) StepOption {
+	return func(s *tektonv1.Step) {
+		s.ComputeResources = resources
+	}
+}
+
+func StepTimeout(timeout time.Duration) StepOption {
+	return func(s *tektonv1.Step) {
+		s.Timeout = &metav1.Duration{Duration: timeout}
+	}
+}
+
+// ContinueOnError lets the task go on when the step fails.
+func ContinueOnError() StepOption {
+	return func(s *tektonv1.Step) {
+		s.OnError = tektonv1.Continue
+	}
+}
+
+// StepActionRef makes the step reference a StepAction instead of declaring an image;
+// pass "" as the step image when using it.
+func StepActionRef(name string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.Ref = &tektonv1.Ref{Name: name}
+	}
+}
+
+// StepParam passes a param to a StepAction referenced with StepActionRef.
+func StepParam(name, value string) StepOption {
+	return func(s *tektonv1.Step) {
+		s.Params = append(s.Params, tektonv1.Param{Name: name, Value: *tektonv1.NewStructuredValues(value)})
+	}
+}
+
+type WorkspaceOption func(*tektonv1.WorkspaceDeclaration)
+
+func MountPath(path string) WorkspaceOption {
+	return func(w *tektonv1.WorkspaceDeclaration) {
+		w.MountPath = path
+	}
+}
+
+func ReadOnly() WorkspaceOption {
+	return func(w *tektonv1.WorkspaceDeclaration) {
+		w.ReadOnly = true
+	}
+}
+
+func Optional() WorkspaceOption {
+	return func(w *tektonv1.WorkspaceDeclaration) {
+		w.Optional = true
+	}
+}
+
+func WorkspaceDescription(description string) WorkspaceOption {
+	return func(w *tektonv1.WorkspaceDeclaration) {
+		w.Description = description
+	}
+}
+
+type ResultOption func(*tektonv1.TaskResult)
+
+func ArrayResult() ResultOption {
+	return func(r *tektonv1.TaskResult) {
+		r.Type = tektonv1.ResultsTypeArray
+	}
+}
+
+// ObjectResult declares an object result with string properties keys.
+func ObjectResult(keys ...string) ResultOption {
+	return func(r *tektonv1.TaskResult) {
+		r.Type = tektonv1.ResultsTypeObject
+		r.Properties = make(map[string]tektonv1.PropertySpec, len(keys))
+		for _, key := range keys {
+			r.Properties[key] = tektonv1.PropertySpec{Type: tektonv1.ParamTypeString}
+		}
+	}
+}
+
+func ResultDescription(description string) ResultOption {
+	return func(r *tektonv1.TaskResult) {
+		r.Description = description
+	}
+}
@@ -0,0 +1,203 @@
+package builder
+
+import (
+	"context"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// PipelineBuilder assembles a tektonv1.Pipeline. Task and Finally add a pipeline task and make
+// it current; RunAfter, TaskParam, TaskWorkspace, When, Retries and Timeout apply to the current one.
+//
+//	pipeline, err := builder.Pipeline("build").
+//		Workspace("source").
+//		Task("clone", "git-clone").TaskParam("url", "$(params.repo-url)").TaskWorkspace("output", "source").
+//		Task("build", "golang-build").RunAfter("clone").TaskWorkspace("source", "source").
+//		Finally("notify", "send-to-webhook").
+//		Param("repo-url").
+//		Build()
+type PipelineBuilder struct {
+	pipeline *tektonv1.Pipeline
+	// current is the task being configured: an index into Tasks, or into Finally when finally is set
+	current int
+	finally bool
+	errs    []string
+}
+
+func Pipeline(name string) *PipelineBuilder {
+	return &PipelineBuilder{
+		pipeline: &tektonv1.Pipeline{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		},
+		current: -1,
+	}
+}
+
+func (b *PipelineBuilder) Namespace(namespace string) *PipelineBuilder {
+	b.pipeline.Namespace = namespace
+	return b
+}
+
+func (b *PipelineBuilder) Label(key, value string) *PipelineBuilder {
+	b.pipeline.Labels = setKey(b.pipeline.Labels, key, value)
+	return b
+}
+
+func (b *PipelineBuilder) Annotation(key, value string) *PipelineBuilder {
+	b.pipeline.Annotations = setKey(b.pipeline.Annotations, key, value)
+	return b
+}
+
+func (b *PipelineBuilder) Description(description string) *PipelineBuilder {
+	b.pipeline.Spec.Description = description
+	return b
+}
+
+func (b *PipelineBuilder) Param(name string, opts ...ParamOption) *PipelineBuilder {
+	b.pipeline.Spec.Params = append(b.pipeline.Spec.Params, newParamSpec(name, opts))
+	return b
+}
+
+func (b *PipelineBuilder) Workspace(name string) *PipelineBuilder {
+	b.pipeline.Spec.Workspaces = append(b.pipeline.Spec.Workspaces, tektonv1.PipelineWorkspaceDeclaration{Name: name})
+	return b
+}
+
+func (b *PipelineBuilder) OptionalWorkspace(name string) *PipelineBuilder {
+	b.pipeline.Spec.Workspaces = append(b.pipeline.Spec.Workspaces, tektonv1.PipelineWorkspaceDeclaration{Name: name, Optional: true})
+	return b
+}
+
+// Result declares a pipeline result, e.g. Result("image", "$(tasks.build.results.IMAGE_URL)").
+func (b *PipelineBuilder) Result(name, value string) *PipelineBuilder {
+	b.pipeline.Spec.Results = append(b.pipeline.Spec.Results, tektonv1.PipelineResult{
+		Name:  name,
+		Type:  tektonv1.ResultsTypeString,
+		Value: *tektonv1.NewStructuredValues(value),
+	})
+	return b
+}
+
+// Task adds a pipeline task referencing the Task taskRef.
+func (b *PipelineBuilder) Task(name, taskRef string) *PipelineBuilder {
+	return b.addTask(tektonv1.PipelineTask{
+		Name:    name,
+		TaskRef: &tektonv1.TaskRef{Name: taskRef, Kind: tektonv1.NamespacedTaskKind},
+	}, false)
+}
+
+// TaskSpec adds a pipeline task embedding the spec of task.
+func (b *PipelineBuilder) TaskSpec(name string, task *TaskBuilder) *PipelineBuilder {
+	return b.addTask(tektonv1.PipelineTask{
+		Name:     name,
+		TaskSpec: &tektonv1.EmbeddedTask{TaskSpec: task.Spec()},
+	}, false)
+}
+
+// Finally adds a finally task referencing the Task taskRef.
+func (b *PipelineBuilder) Finally(name, taskRef string) *PipelineBuilder {
+	return b.addTask(tektonv1.PipelineTask{
+		Name:    name,
+		TaskRef: &tektonv1.TaskRef{Name: taskRef, Kind: tektonv1.NamespacedTaskKind},
+	}, true)
+}
+
+// FinallySpec adds a finally task embedding the spec of task.
+func (b *PipelineBuilder) FinallySpec(name string, task *TaskBuilder) *PipelineBuilder {
+	return b.addTask(tektonv1.PipelineTask{
+		Name:     name,
+		TaskSpec: &tektonv1.EmbeddedTask{TaskSpec: task.Spec()},
+	}, true)
+}
+
+func (b *PipelineBuilder) RunAfter(tasks ...string) *PipelineBuilder {
+	if pt := b.currentTask("RunAfter"); pt != nil {
+		pt.RunAfter = append(pt.RunAfter, tasks...)
+	}
+	return b
+}
+
+func (b *PipelineBuilder) TaskParam(name, value string) *PipelineBuilder {
+	if pt := b.currentTask("TaskParam"); pt != nil {
+		pt.Params = append(pt.Params, tektonv1.Param{Name: name, Value: *tektonv1.NewStructuredValues(value)})
+	}
+	return b
+}
+
+func (b *PipelineBuilder) TaskArrayParam(name string, values ...string) *PipelineBuilder {
+	if pt := b.currentTask("TaskArrayParam"); pt != nil {
+		pt.Params = append(pt.Params, tektonv1.Param{Name: name, Value: tektonv1.ParamValue{Type: tektonv1.ParamTypeArray, ArrayVal: values}})
+	}
+	return b
+}
+
+// TaskWorkspace binds the task workspace name to the pipeline workspace workspace.
+func (b *PipelineBuilder) TaskWorkspace(name, workspace string) *PipelineBuilder {
+	if pt := b.currentTask("TaskWorkspace"); pt != nil {
+		pt.Workspaces = append(pt.Workspaces, tektonv1.WorkspacePipelineTaskBinding{Name: name, Workspace: workspace})
+	}
+	return b
+}
+
+// When guards the current task, e.g. When("$(params.branch)", selection.In, "main").
+func (b *PipelineBuilder) When(input string, operator selection.Operator, values ...string) *PipelineBuilder {
+	if pt := b.currentTask("When"); pt != nil {
+		pt.When = append(pt.When, tektonv1.WhenExpression{Input: input, Operator: operator, Values: values})
+	}
+	return b
+}
+
+func (b *PipelineBuilder) Retries(retries int) *PipelineBuilder {
+	if pt := b.currentTask("Retries"); pt != nil {
+		pt.Retries = retries
+	}
+	return b
+}
+
+func (b *PipelineBuilder) Timeout(timeout time.Duration) *PipelineBuilder {
+	if pt := b.currentTask("Timeout"); pt != nil {
+		pt.Timeout = &metav1.Duration{Duration: timeout}
+	}
+	return b
+}
+
+// Build validates the pipeline with Tekton's own webhook rules and returns it.
+func (b *PipelineBuilder) Build() (*tektonv1.Pipeline, error) {
+	if len(b.errs) > 0 {
+		return nil, errorx.NewDefaultError("%s called before Task or Finally", b.errs[0])
+	}
+	pipeline := b.pipeline.DeepCopy()
+	if err := validation.Validate(context.Background(), pipeline); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+func (b *PipelineBuilder) addTask(pt tektonv1.PipelineTask, finally bool) *PipelineBuilder {
+	if finally {
+		b.pipeline.Spec.Finally = append(b.pipeline.Spec.Finally, pt)
+		b.current = len(b.pipeline.Spec.Finally) - 1
+	} else {
+		b.pipeline.Spec.Tasks = append(b.pipeline.Spec.Tasks, pt)
+		b.current = len(b.pipeline.Spec.Tasks) - 1
+	}
+	b.finally = finally
+	return b
+}
+
+func (b *PipelineBuilder) currentTask(method string) *tektonv1.PipelineTask {
+	if b.current < 0 {
+		b.errs = append(b.errs, method)
+		return nil
+	}
+	if b.finally {
+		return &b.pipeline.Spec.Finally[b.current]
+	}
+	return &b.pipeline.Spec.Tasks[b.current]
+}
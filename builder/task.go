@@ -0,0 +1,150 @@
+package builder
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaskBuilder assembles a tektonv1.Task.
+//
+//	task, err := builder.Task("echo").
+//		Param("message", builder.Default("hello")).
+//		Step("echo", "alpine", builder.Script("echo $(params.message)")).
+//		Workspace("source", builder.MountPath("/workspace/source")).
+//		Build()
+type TaskBuilder struct {
+	task *tektonv1.Task
+	errs []string
+}
+
+func Task(name string) *TaskBuilder {
+	return &TaskBuilder{
+		task: &tektonv1.Task{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		},
+	}
+}
+
+func (b *TaskBuilder) Namespace(namespace string) *TaskBuilder {
+	b.task.Namespace = namespace
+	return b
+}
+
+func (b *TaskBuilder) Label(key, value string) *TaskBuilder {
+	b.task.Labels = setKey(b.task.Labels, key, value)
+	return b
+}
+
+func (b *TaskBuilder) Annotation(key, value string) *TaskBuilder {
+	b.task.Annotations = setKey(b.task.Annotations, key, value)
+	return b
+}
+
+func (b *TaskBuilder) Description(description string) *TaskBuilder {
+	b.task.Spec.Description = description
+	return b
+}
+
+func (b *TaskBuilder) Param(name string, opts ...ParamOption) *TaskBuilder {
+	b.task.Spec.Params = append(b.task.Spec.Params, newParamSpec(name, opts))
+	return b
+}
+
+func (b *TaskBuilder) Step(name, image string, opts ...StepOption) *TaskBuilder {
+	step := tektonv1.Step{Name: name, Image: image}
+	for _, opt := range opts {
+		opt(&step)
+	}
+	b.task.Spec.Steps = append(b.task.Spec.Steps, step)
+	return b
+}
+
+// StepTemplate sets the fields shared by every step, e.g. builder.Env("HOME", "/tekton/home").
+// Options setting a field a step template does not have, e.g. Script or StepActionRef, make Build fail.
+func (b *TaskBuilder) StepTemplate(opts ...StepOption) *TaskBuilder {
+	var step tektonv1.Step
+	for _, opt := range opts {
+		opt(&step)
+	}
+	b.task.Spec.StepTemplate = &tektonv1.StepTemplate{
+		Image:            step.Image,
+		Command:          step.Command,
+		Args:             step.Args,
+		WorkingDir:       step.WorkingDir,
+		EnvFrom:          step.EnvFrom,
+		Env:              step.Env,
+		ComputeResources: step.ComputeResources,
+		VolumeMounts:     step.VolumeMounts,
+		VolumeDevices:    step.VolumeDevices,
+		ImagePullPolicy:  step.ImagePullPolicy,
+		SecurityContext:  step.SecurityContext,
+	}
+	// what is left once the template fields are cleared has no place in the template
+	rest := step
+	rest.Image, rest.Command, rest.Args, rest.WorkingDir = "", nil, nil, ""
+	rest.EnvFrom, rest.Env, rest.ComputeResources = nil, nil, corev1.ResourceRequirements{}
+	rest.VolumeMounts, rest.VolumeDevices, rest.ImagePullPolicy, rest.SecurityContext = nil, nil, "", nil
+	v := reflect.ValueOf(rest)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			b.errs = append(b.errs, v.Type().Field(i).Name)
+		}
+	}
+	return b
+}
+
+func (b *TaskBuilder) Workspace(name string, opts ...WorkspaceOption) *TaskBuilder {
+	workspace := tektonv1.WorkspaceDeclaration{Name: name}
+	for _, opt := range opts {
+		opt(&workspace)
+	}
+	b.task.Spec.Workspaces = append(b.task.Spec.Workspaces, workspace)
+	return b
+}
+
+func (b *TaskBuilder) Result(name string, opts ...ResultOption) *TaskBuilder {
+	result := tektonv1.TaskResult{Name: name}
+	for _, opt := range opts {
+		opt(&result)
+	}
+	b.task.Spec.Results = append(b.task.Spec.Results, result)
+	return b
+}
+
+func (b *TaskBuilder) Volume(volume corev1.Volume) *TaskBuilder {
+	b.task.Spec.Volumes = append(b.task.Spec.Volumes, volume)
+	return b
+}
+
+// Spec returns the task spec built so far without validating it, for embedding in a pipeline.
+func (b *TaskBuilder) Spec() tektonv1.TaskSpec {
+	return *b.task.Spec.DeepCopy()
+}
+
+// Build validates the task with Tekton's own webhook rules and returns it.
+func (b *TaskBuilder) Build() (*tektonv1.Task, error) {
+	if len(b.errs) > 0 {
+		return nil, errorx.NewDefaultError("StepTemplate cannot set %s", strings.Join(b.errs, ", "))
+	}
+	task := b.task.DeepCopy()
+	if err := validation.Validate(context.Background(), task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func setKey(m map[string]string, key, value string) map[string]string {
+	if m == nil {
+		m = make(map[string]string)
+	}
+	m[key] = value
+	return m
+}
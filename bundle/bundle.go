@@ -0,0 +1,283 @@
+// Package bundle packs Tekton objects into Tekton bundles, OCI images holding one object per layer
+// with the dev.tekton.image.* layer annotations the bundles resolver looks objects up by, and
+// unpacks them. Bundles are pushed to and pulled from any registry, or kept in OCI image layouts on disk.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AnnotationKind is the lowercase, singular kind of the object of a layer, e.g. task
+	AnnotationKind       = "dev.tekton.image.kind"
+	AnnotationName       = "dev.tekton.image.name"
+	AnnotationAPIVersion = "dev.tekton.image.apiVersion"
+	// MaxObjects is the maximum number of objects in a bundle the bundles resolver accepts
+	MaxObjects = 20
+)
+
+type Options struct {
+	// Keychain authenticates to the registry, the docker config of the user when nil
+	Keychain authn.Keychain
+	// Insecure allows registries served over plain HTTP, e.g. a local test registry
+	Insecure bool
+}
+
+// Build packs objs into a bundle, in order, with their status and server-owned metadata removed,
+// see manifest.Clean.
+func Build(objs []*unstructured.Unstructured) (v1.Image, error) {
+	if len(objs) > MaxObjects {
+		return nil, errorx.NewDefaultError("a bundle holds at most %d objects, got %d", MaxObjects, len(objs))
+	}
+	img := empty.Image
+	seen := make(map[string]bool)
+	for _, obj := range objs {
+		kind := strings.ToLower(obj.GetKind())
+		if kind == "" || obj.GetName() == "" || obj.GetAPIVersion() == "" {
+			return nil, errorx.NewDefaultError("object %s/%s has no apiVersion, kind or name", obj.GetKind(), obj.GetName())
+		}
+		if seen[kind+"/"+obj.GetName()] {
+			return nil, errorx.NewDefaultError("duplicate %s %s in bundle", obj.GetKind(), obj.GetName())
+		}
+		seen[kind+"/"+obj.GetName()] = true
+		clean := obj.DeepCopy()
+		manifest.Clean(clean)
+		data, err := yaml.Marshal(clean.Object)
+		if err != nil {
+			return nil, errorx.NewDefaultError("cannot marshal %s %s: %s", obj.GetKind(), obj.GetName(), err.Error())
+		}
+		layer, err := tarLayer(obj.GetName(), data)
+		if err != nil {
+			return nil, err
+		}
+		if img, err = mutate.Append(img, mutate.Addendum{
+			Layer: layer,
+			Annotations: map[string]string{
+				AnnotationKind:       kind,
+				AnnotationName:       obj.GetName(),
+				AnnotationAPIVersion: obj.GetAPIVersion(),
+			},
+		}); err != nil {
+			return nil, errorx.NewDefaultError("cannot add %s %s to bundle: %s", obj.GetKind(), obj.GetName(), err.Error())
+		}
+	}
+	return img, nil
+}
+
+// BuildYaml packs the documents of a multi-document YAML, e.g. one written by Client.Export.
+func BuildYaml(yamlStr string) (v1.Image, error) {
+	objs, err := manifest.Unmarshal([]byte(yamlStr))
+	if err != nil {
+		return nil, err
+	}
+	return Build(objs)
+}
+
+// Unpack returns the objects of a bundle in layer order. A layer is a tarball holding the object,
+// or the raw object for bundles written by older tools.
+func Unpack(img v1.Image) ([]*unstructured.Unstructured, error) {
+	m, err := img.Manifest()
+	if err != nil {
+		return nil, errorx.NewDefaultError("cannot read bundle manifest: %s", err.Error())
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, errorx.NewDefaultError("cannot read bundle layers: %s", err.Error())
+	}
+	var objs []*unstructured.Unstructured
+	for i, desc := range m.Layers {
+		if desc.Annotations[AnnotationKind] == "" || desc.Annotations[AnnotationName] == "" || desc.Annotations[AnnotationAPIVersion] == "" {
+			return nil, errorx.NewDefaultError("layer %d is not a Tekton object, it has no %s, %s or %s annotation", i, AnnotationKind, AnnotationName, AnnotationAPIVersion)
+		}
+		data, err := readLayer(layers[i])
+		if err != nil {
+			return nil, errorx.NewDefaultError("cannot read layer %d (%s %s): %s", i, desc.Annotations[AnnotationKind], desc.Annotations[AnnotationName], err.Error())
+		}
+		obj := make(map[string]interface{})
+		if err = yaml.Unmarshal(data, &obj); err != nil {
+			return nil, errorx.NewDefaultError("layer %d (%s %s) is not YAML or JSON: %s", i, desc.Annotations[AnnotationKind], desc.Annotations[AnnotationName], err.Error())
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: obj})
+	}
+	return objs, nil
+}
+
+// UnpackYaml returns the objects of a bundle as a multi-document YAML.
+func UnpackYaml(img v1.Image) (string, error) {
+	objs, err := Unpack(img)
+	if err != nil {
+		return "", err
+	}
+	data, err := manifest.Marshal(objs)
+	return string(data), err
+}
+
+// Get returns the object of a bundle with kind and name, the way the bundles resolver looks it up.
+func Get(img v1.Image, kind, name string) (*unstructured.Unstructured, error) {
+	objs, err := Unpack(img)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		if strings.EqualFold(obj.GetKind(), kind) && obj.GetName() == name {
+			return obj, nil
+		}
+	}
+	return nil, errorx.NewDefaultError("bundle has no %s %s", kind, name)
+}
+
+// Push pushes the bundle to ref in a registry and returns its digest, e.g. sha256:4f1d...
+func Push(ctx context.Context, ref string, img v1.Image, opts ...Options) (string, error) {
+	r, remoteOpts, err := parse(ctx, ref, opts)
+	if err != nil {
+		return "", err
+	}
+	if err = remote.Write(r, img, remoteOpts...); err != nil {
+		return "", errorx.NewDefaultError("cannot push bundle %s: %s", ref, err.Error())
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+// Pull pulls the bundle at ref, a tag or a digest, from a registry.
+func Pull(ctx context.Context, ref string, opts ...Options) (v1.Image, error) {
+	r, remoteOpts, err := parse(ctx, ref, opts)
+	if err != nil {
+		return nil, err
+	}
+	img, err := remote.Image(r, remoteOpts...)
+	if err != nil {
+		return nil, errorx.NewDefaultError("cannot pull bundle %s: %s", ref, err.Error())
+	}
+	return img, nil
+}
+
+// WriteLayout writes the bundle to the OCI image layout at path, created when missing, under the
+// name ref; a bundle already written under ref is replaced. It returns the digest of the bundle.
+func WriteLayout(path, ref string, img v1.Image) (string, error) {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		if _, statErr := os.Stat(path); statErr == nil {
+			return "", errorx.NewDefaultError("%s is not an OCI image layout: %s", path, err.Error())
+		}
+		if p, err = layout.Write(path, empty.Index); err != nil {
+			return "", errorx.NewDefaultError("cannot create OCI image layout %s: %s", path, err.Error())
+		}
+	}
+	if err = p.ReplaceImage(img, match.Name(ref), layout.WithAnnotations(map[string]string{
+		"org.opencontainers.image.ref.name": ref,
+	})); err != nil {
+		return "", errorx.NewDefaultError("cannot write bundle %s to %s: %s", ref, path, err.Error())
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+// ReadLayout reads the bundle named ref from the OCI image layout at path; an empty ref reads the
+// only image of the layout.
+func ReadLayout(path, ref string) (v1.Image, error) {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		return nil, errorx.NewDefaultError("%s is not an OCI image layout: %s", path, err.Error())
+	}
+	index, err := p.ImageIndex()
+	if err != nil {
+		return nil, errorx.NewDefaultError("cannot read OCI image layout %s: %s", path, err.Error())
+	}
+	m, err := index.IndexManifest()
+	if err != nil {
+		return nil, errorx.NewDefaultError("cannot read OCI image layout %s: %s", path, err.Error())
+	}
+	var found []v1.Descriptor
+	for _, desc := range m.Manifests {
+		if ref == "" || match.Name(ref)(desc) {
+			found = append(found, desc)
+		}
+	}
+	switch {
+	case len(found) == 0:
+		return nil, errorx.NewDefaultError("no bundle %s in %s", ref, path)
+	case len(found) > 1:
+		return nil, errorx.NewDefaultError("%d images in %s, read one by its name", len(found), path)
+	}
+	return index.Image(found[0].Digest)
+}
+
+func parse(ctx context.Context, ref string, opts []Options) (name.Reference, []remote.Option, error) {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	var nameOpts []name.Option
+	if opt.Insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	r, err := name.ParseReference(ref, nameOpts...)
+	if err != nil {
+		return nil, nil, errorx.NewDefaultError("%s is not an image reference: %s", ref, err.Error())
+	}
+	keychain := opt.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	return r, []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}, nil
+}
+
+// tarLayer is a layer holding data as the file name, like tkn bundle push writes it.
+func tarLayer(name string, data []byte) (v1.Layer, error) {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return tarball.LayerFromReader(&buf)
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	raw, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(bytes.NewReader(raw))
+	if _, err = tr.Next(); err != nil {
+		// not a tarball, the layer is the object itself
+		return raw, nil
+	}
+	return io.ReadAll(tr)
+}
@@ -8,13 +8,16 @@ import (
 	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
 	"github.com/hongyuxuan/tekton-sdk-go/core/option"
 	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/service/approval"
 	v1 "github.com/hongyuxuan/tekton-sdk-go/service/v1"
+	v1alpha1 "github.com/hongyuxuan/tekton-sdk-go/service/v1alpha1"
 	v1beta1 "github.com/hongyuxuan/tekton-sdk-go/service/v1beta1"
 	"github.com/imroc/req/v3"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/util/flowcontrol"
 )
 
@@ -28,21 +31,33 @@ func NewClient(opts ...option.ClientOptionFunc) *Client {
 	for _, opt := range opts {
 		opt(config)
 	}
+	client, err := newClient(config)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
 
+func newClient(config *config.Config) (*Client, error) {
 	clientset, dynamicclient, token, baseUrl, err := createKubernetes(config)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	httpclient := req.C().
 		OnBeforeRequest(func(client *req.Client, req *req.Request) error {
-			if req.RetryAttempt > 0 {
+			// a watch streams for minutes, do not keep its whole response in the dump buffer
+			if req.RetryAttempt > 0 || req.QueryParams.Get("watch") == "true" {
 				return nil
 			}
 			req.EnableDump()
 			return nil
 		}).
 		OnAfterResponse(func(client *req.Client, res *req.Response) (err error) {
+			// no response to read, e.g. the context of the request is done
+			if res.Response == nil {
+				return res.Err
+			}
 			responseCode := strconv.Itoa(res.StatusCode)
 			if !strings.HasPrefix(responseCode, "2") && !strings.HasPrefix(responseCode, "3") {
 				defer func() {
@@ -71,20 +86,44 @@ func NewClient(opts ...option.ClientOptionFunc) *Client {
 	return &Client{
 		Config: config,
 		svcCtx: service.NewServiceContext(clientset, dynamicclient, config.SecretPrefix, token),
-	}
+	}, nil
 }
 
 func createKubernetes(c *config.Config) (clientset *kubernetes.Clientset, dynamicclient dynamic.Interface, token, baseUrl string, err error) {
 	var conf *rest.Config
-	if c.Kubeconfig != "" {
-		conf, err = clientcmd.BuildConfigFromFlags("", c.Kubeconfig)
-	} else {
-		conf, err = rest.InClusterConfig()
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: c.Context}
+	switch {
+	case c.RestConfig != nil:
+		conf = rest.CopyConfig(c.RestConfig)
+	case len(c.KubeconfigBytes) > 0:
+		var raw *clientcmdapi.Config
+		if raw, err = clientcmd.Load(c.KubeconfigBytes); err != nil {
+			return
+		}
+		conf, err = clientcmd.NewNonInteractiveClientConfig(*raw, c.Context, overrides, nil).ClientConfig()
+	case c.Kubeconfig != "":
+		conf, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.Kubeconfig},
+			overrides,
+		).ClientConfig()
+	case c.UseKubeconfigEnv:
+		conf, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(),
+			overrides,
+		).ClientConfig()
+	default:
+		if conf, err = rest.InClusterConfig(); err != nil {
+			err = errorx.NewDefaultError("no kubeconfig given and not running in a cluster: %s", err.Error())
+			return
+		}
 		conf.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(1000, 1000) // setting a big ratelimiter for client-side throttling, default 5
 	}
 	if err != nil {
 		return
 	}
+	if c.BaseUrl != "" {
+		conf.Host = c.BaseUrl
+	}
 	clientset, err = kubernetes.NewForConfig(conf)
 	if err != nil {
 		return
@@ -110,6 +149,30 @@ func (c *Client) PipelineRun(namespace string) *v1.PipelineRun {
 	return v1.NewPipelineRun(c.Config, namespace, c.svcCtx)
 }
 
+func (c *Client) TaskRun(namespace string) *v1.TaskRun {
+	return v1.NewTaskRun(c.Config, namespace, c.svcCtx)
+}
+
+func (c *Client) StepAction(namespace string) *v1beta1.StepAction {
+	return v1beta1.NewStepAction(c.Config, namespace, c.svcCtx)
+}
+
+func (c *Client) CustomRun(namespace string) *v1beta1.CustomRun {
+	return v1beta1.NewCustomRun(c.Config, namespace, c.svcCtx)
+}
+
+func (c *Client) ResolutionRequest(namespace string) *v1beta1.ResolutionRequest {
+	return v1beta1.NewResolutionRequest(c.Config, namespace, c.svcCtx)
+}
+
+func (c *Client) ApprovalTask(namespace string) *v1alpha1.ApprovalTask {
+	return v1alpha1.NewApprovalTask(c.Config, namespace, c.svcCtx)
+}
+
+func (c *Client) Approval(namespace string) *approval.Approval {
+	return approval.NewApproval(c.Config, namespace, c.svcCtx)
+}
+
 func (c *Client) TriggerBinding(namespace string) *v1beta1.TriggerBinding {
 	return v1beta1.NewTriggerBinding(c.Config, namespace, c.svcCtx)
 }
@@ -118,6 +181,22 @@ func (c *Client) TriggerTemplate(namespace string) *v1beta1.TriggerTemplate {
 	return v1beta1.NewTriggerTemplate(c.Config, namespace, c.svcCtx)
 }
 
+func (c *Client) ClusterTriggerBinding() *v1beta1.ClusterTriggerBinding {
+	return v1beta1.NewClusterTriggerBinding(c.Config, c.svcCtx)
+}
+
+func (c *Client) Interceptor(namespace string) *v1alpha1.Interceptor {
+	return v1alpha1.NewInterceptor(c.Config, namespace, c.svcCtx)
+}
+
+func (c *Client) ClusterInterceptor() *v1alpha1.ClusterInterceptor {
+	return v1alpha1.NewClusterInterceptor(c.Config, c.svcCtx)
+}
+
+func (c *Client) Trigger(namespace string) *v1beta1.Trigger {
+	return v1beta1.NewTrigger(c.Config, namespace, c.svcCtx)
+}
+
 func (c *Client) EventListener(namespace string) *v1beta1.EventListener {
 	return v1beta1.NewEventListener(c.Config, namespace, c.svcCtx)
 }
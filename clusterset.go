@@ -0,0 +1,209 @@
+package tekton
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/core/option"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ClusterSet holds one Client per cluster, keyed by cluster name.
+type ClusterSet struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+func NewClusterSet() *ClusterSet {
+	return &ClusterSet{clients: make(map[string]*Client)}
+}
+
+// NewClusterSetFromContexts creates a client for each of the given contexts in kubeconfig,
+// or for every context in it when contexts is empty. Clusters are named after their context.
+func NewClusterSetFromContexts(kubeconfig string, contexts []string, opts ...option.ClientOptionFunc) (*ClusterSet, error) {
+	if len(contexts) == 0 {
+		raw, err := clientcmd.LoadFromFile(kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		for name := range raw.Contexts {
+			contexts = append(contexts, name)
+		}
+		sort.Strings(contexts)
+	}
+	cs := NewClusterSet()
+	for _, name := range contexts {
+		client, err := newClusterClient(opts, option.WithKubeconfig(kubeconfig), option.WithContext(name))
+		if err != nil {
+			return nil, errorx.NewDefaultError("cluster %s: %s", name, err.Error())
+		}
+		cs.Add(name, client)
+	}
+	return cs, nil
+}
+
+// NewClusterSetFromDir creates a client for every kubeconfig file in dir, using each file's
+// current context. Clusters are named after the file name without its extension.
+func NewClusterSetFromDir(dir string, opts ...option.ClientOptionFunc) (*ClusterSet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	cs := NewClusterSet()
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		client, err := newClusterClient(opts, option.WithKubeconfig(filepath.Join(dir, entry.Name())))
+		if err != nil {
+			return nil, errorx.NewDefaultError("cluster %s: %s", name, err.Error())
+		}
+		cs.Add(name, client)
+	}
+	return cs, nil
+}
+
+func newClusterClient(opts []option.ClientOptionFunc, overrides ...option.ClientOptionFunc) (*Client, error) {
+	config := &config.Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	for _, opt := range overrides {
+		opt(config)
+	}
+	return newClient(config)
+}
+
+// Add registers client under name, replacing any client already registered with that name.
+func (cs *ClusterSet) Add(name string, client *Client) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.clients[name] = client
+}
+
+func (cs *ClusterSet) Remove(name string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	delete(cs.clients, name)
+}
+
+func (cs *ClusterSet) Cluster(name string) (*Client, bool) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	client, ok := cs.clients[name]
+	return client, ok
+}
+
+// Names returns the cluster names in sorted order.
+func (cs *ClusterSet) Names() []string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	names := make([]string, 0, len(cs.clients))
+	for name := range cs.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClusterResult is the outcome of a fan-out call against a single cluster.
+type ClusterResult[T any] struct {
+	Cluster string
+	Items   []T
+	Err     error
+}
+
+// ListAcross calls list against every cluster in cs concurrently and returns one result per
+// cluster, ordered by cluster name. A failing cluster does not abort the others.
+//
+//	res := tekton.ListAcross(ctx, cs, func(ctx context.Context, c *tekton.Client) ([]tektonv1.Pipeline, error) {
+//		return c.Pipeline("default").List(ctx, metav1.ListOptions{})
+//	})
+func ListAcross[T any](ctx context.Context, cs *ClusterSet, list func(context.Context, *Client) ([]T, error)) []ClusterResult[T] {
+	names := cs.Names()
+	results := make([]ClusterResult[T], len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		client, _ := cs.Cluster(name)
+		wg.Add(1)
+		go func(i int, name string, client *Client) {
+			defer wg.Done()
+			defer func() {
+				// service constructors panic when the bearer token cannot be found
+				if e := recover(); e != nil {
+					results[i] = ClusterResult[T]{Cluster: name, Err: errorx.NewDefaultError("%v", e)}
+				}
+			}()
+			items, err := list(ctx, client)
+			results[i] = ClusterResult[T]{Cluster: name, Items: items, Err: err}
+		}(i, name, client)
+	}
+	wg.Wait()
+	return results
+}
+
+type ClusterHealth struct {
+	Cluster string
+	Healthy bool
+	// Version is the Kubernetes server version, e.g. v1.29.6
+	Version string
+	// TektonAPIs lists the Tekton group versions served by the cluster, e.g. tekton.dev/v1
+	TektonAPIs []string
+	Err        error
+}
+
+var tektonGroupVersions = []string{
+	"tekton.dev/v1",
+	"tekton.dev/v1beta1",
+	"triggers.tekton.dev/v1beta1",
+}
+
+// HealthCheck probes every cluster concurrently. A cluster is healthy when its API server
+// answers /readyz and serves the tekton.dev/v1 API.
+func (cs *ClusterSet) HealthCheck(ctx context.Context) []ClusterHealth {
+	names := cs.Names()
+	results := make([]ClusterHealth, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		client, _ := cs.Cluster(name)
+		wg.Add(1)
+		go func(i int, name string, client *Client) {
+			defer wg.Done()
+			results[i] = client.healthCheck(ctx)
+			results[i].Cluster = name
+		}(i, name, client)
+	}
+	wg.Wait()
+	return results
+}
+
+func (c *Client) healthCheck(ctx context.Context) (health ClusterHealth) {
+	discovery := c.svcCtx.Clientset.Discovery()
+	if health.Err = discovery.RESTClient().Get().AbsPath("/readyz").Do(ctx).Error(); health.Err != nil {
+		return
+	}
+	version, err := discovery.ServerVersion()
+	if err != nil {
+		health.Err = err
+		return
+	}
+	health.Version = version.GitVersion
+	for _, gv := range tektonGroupVersions {
+		if _, err := discovery.ServerResourcesForGroupVersion(gv); err == nil {
+			health.TektonAPIs = append(health.TektonAPIs, gv)
+		}
+	}
+	if len(health.TektonAPIs) == 0 || health.TektonAPIs[0] != tektonGroupVersions[0] {
+		health.Err = errorx.NewDefaultError("tekton.dev/v1 is not served by the cluster")
+		return
+	}
+	health.Healthy = true
+	return
+}
@@ -2,11 +2,17 @@ package config
 
 import (
 	"github.com/imroc/req/v3"
+	"k8s.io/client-go/rest"
 )
 
 type Config struct {
-	Kubeconfig   string
-	SecretPrefix string
-	EnableDebug  bool
-	Httpclient   *req.Client
+	Kubeconfig       string
+	KubeconfigBytes  []byte
+	UseKubeconfigEnv bool
+	Context          string
+	RestConfig       *rest.Config
+	BaseUrl          string
+	SecretPrefix     string
+	EnableDebug      bool
+	Httpclient       *req.Client
 }
@@ -2,6 +2,7 @@ package option
 
 import (
 	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"k8s.io/client-go/rest"
 )
 
 type ClientOptionFunc func(*config.Config)
@@ -17,17 +18,47 @@ func WithKubeconfig(kubeconfig string) ClientOptionFunc {
 	}
 }
 
+// WithKubeconfigBytes loads the kubeconfig from memory instead of a file.
+func WithKubeconfigBytes(kubeconfig []byte) ClientOptionFunc {
+	return func(c *config.Config) {
+		c.KubeconfigBytes = kubeconfig
+	}
+}
+
+// WithKubeconfigEnv loads the kubeconfig the way kubectl does: the files listed in the
+// KUBECONFIG environment variable are merged, falling back to ~/.kube/config.
+func WithKubeconfigEnv() ClientOptionFunc {
+	return func(c *config.Config) {
+		c.UseKubeconfigEnv = true
+	}
+}
+
+// WithRestConfig uses an existing rest.Config, e.g. the one held by a controller manager.
+func WithRestConfig(restConfig *rest.Config) ClientOptionFunc {
+	return func(c *config.Config) {
+		c.RestConfig = restConfig
+	}
+}
+
+// WithContext selects a named context in the kubeconfig instead of its current-context.
+func WithContext(context string) ClientOptionFunc {
+	return func(c *config.Config) {
+		c.Context = context
+	}
+}
+
 func WithSecretPrefix(secretPrefix string) ClientOptionFunc {
 	return func(c *config.Config) {
 		c.SecretPrefix = secretPrefix
 	}
 }
 
-// func WithBaseUrl(baseUrl string) ClientOptionFunc {
-// 	return func(c *config.Config) {
-// 		c.BaseUrl = baseUrl
-// 	}
-// }
+// WithBaseUrl overrides the API server URL of whichever config is loaded.
+func WithBaseUrl(baseUrl string) ClientOptionFunc {
+	return func(c *config.Config) {
+		c.BaseUrl = baseUrl
+	}
+}
 
 func WithDebug(enable bool) ClientOptionFunc {
 	return func(c *config.Config) {
@@ -0,0 +1,172 @@
+package tekton
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/imroc/req/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ImportOptions struct {
+	// Overwrite updates the objects that already exist in the target namespace, otherwise they are skipped
+	Overwrite bool
+	// DryRun sends every write with dryRun=All, the server validates it but stores nothing
+	DryRun bool
+}
+
+type ImportResult struct {
+	// Created, Updated and Skipped hold the imported objects as Kind/name, in the order they were applied
+	Created []string
+	Updated []string
+	Skipped []string
+}
+
+type ExportResult struct {
+	// Bundle is the multi-document YAML of the exported objects
+	Bundle string
+	// Skipped holds the default kinds that could not be listed by kind, e.g. the Triggers kinds on a
+	// cluster without Tekton Triggers, or a kind the token is not allowed to list
+	Skipped map[string]error
+}
+
+// Export returns the objects of kinds in namespace matching the label selector as a multi-document
+// YAML bundle, with the status and server-owned metadata removed, see manifest.Clean. Objects are
+// ordered by kind, Tasks before Pipelines, then by name. Empty kinds exports types.ConfigKinds,
+// skipping the kinds the server does not serve or the token cannot list; a kind named in kinds
+// that cannot be listed fails the export.
+func (c *Client) Export(ctx context.Context, namespace string, kinds []string, selector string) (*ExportResult, error) {
+	defaulted := len(kinds) == 0
+	if defaulted {
+		kinds = types.ConfigKinds
+	}
+	token, err := c.svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		return nil, err
+	}
+	resourceKinds, err := lookupKinds(kinds)
+	if err != nil {
+		return nil, err
+	}
+	res := &ExportResult{Skipped: make(map[string]error)}
+	var objs []*unstructured.Unstructured
+	for _, kind := range resourceKinds {
+		list, err := service.ListAll[map[string]interface{}](ctx, func() *req.Request {
+			return c.Config.Httpclient.Get(kind.Path(namespace)).SetBearerAuthToken(token)
+		}, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			if defaulted && (isNotFound(err) || isForbidden(err)) {
+				res.Skipped[kind.Kind] = err
+				continue
+			}
+			return nil, err
+		}
+		items := make([]*unstructured.Unstructured, 0, len(list))
+		for _, item := range list {
+			obj := &unstructured.Unstructured{Object: item}
+			obj.SetAPIVersion(kind.APIVersion())
+			obj.SetKind(kind.Kind)
+			manifest.Clean(obj)
+			items = append(items, obj)
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+		objs = append(objs, items...)
+	}
+	bundle, err := manifest.Marshal(objs)
+	if err != nil {
+		return nil, err
+	}
+	res.Bundle = string(bundle)
+	return res, nil
+}
+
+// Import applies a bundle written by Export to targetNamespace, rewriting the namespace of every
+// object. Objects are applied in dependency order whatever their order in the bundle. To copy a
+// namespace to another cluster, export with the client of one cluster and import with the other.
+func (c *Client) Import(ctx context.Context, targetNamespace, bundle string, opts ImportOptions) (*ImportResult, error) {
+	objs, err := manifest.Unmarshal([]byte(bundle))
+	if err != nil {
+		return nil, err
+	}
+	kinds := make(map[*unstructured.Unstructured]types.ResourceKind, len(objs))
+	order := make(map[*unstructured.Unstructured]int, len(objs))
+	for _, obj := range objs {
+		kind, i, ok := types.LookupKind(obj.GetKind())
+		if !ok || kind.APIVersion() != obj.GetAPIVersion() {
+			return nil, errorx.NewDefaultError("unsupported kind %s %s of %s", obj.GetAPIVersion(), obj.GetKind(), obj.GetName())
+		}
+		kinds[obj], order[obj] = kind, i
+	}
+	sort.SliceStable(objs, func(i, j int) bool { return order[objs[i]] < order[objs[j]] })
+
+	token, err := c.svcCtx.GetBearerToken(targetNamespace)
+	if err != nil {
+		return nil, err
+	}
+	res := &ImportResult{}
+	for _, obj := range objs {
+		kind := kinds[obj]
+		manifest.Clean(obj)
+		obj.SetNamespace(targetNamespace)
+		id := obj.GetKind() + "/" + obj.GetName()
+
+		existing := make(map[string]interface{})
+		err = c.Config.Httpclient.Get(kind.Path(targetNamespace) + "/" + obj.GetName()).
+			SetBearerAuthToken(token).
+			SetSuccessResult(&existing).Do(ctx).Err
+		switch {
+		case isNotFound(err):
+			req := c.Config.Httpclient.Post(kind.Path(targetNamespace)).SetBearerAuthToken(token).SetBody(obj.Object)
+			if opts.DryRun {
+				req.SetQueryParam("dryRun", "All")
+			}
+			if err = req.Do(ctx).Err; err != nil {
+				return res, errorx.NewDefaultError("cannot create %s: %s", id, err.Error())
+			}
+			res.Created = append(res.Created, id)
+		case err != nil:
+			return res, err
+		case !opts.Overwrite:
+			res.Skipped = append(res.Skipped, id)
+		default:
+			obj.SetResourceVersion((&unstructured.Unstructured{Object: existing}).GetResourceVersion())
+			req := c.Config.Httpclient.Put(kind.Path(targetNamespace) + "/" + obj.GetName()).SetBearerAuthToken(token).SetBody(obj.Object)
+			if opts.DryRun {
+				req.SetQueryParam("dryRun", "All")
+			}
+			if err = req.Do(ctx).Err; err != nil {
+				return res, errorx.NewDefaultError("cannot update %s: %s", id, err.Error())
+			}
+			res.Updated = append(res.Updated, id)
+		}
+	}
+	return res, nil
+}
+
+func lookupKinds(kinds []string) ([]types.ResourceKind, error) {
+	res := make([]types.ResourceKind, 0, len(kinds))
+	for _, kind := range kinds {
+		k, _, ok := types.LookupKind(kind)
+		if !ok {
+			return nil, errorx.NewDefaultError("unsupported kind %s", kind)
+		}
+		res = append(res, k)
+	}
+	return res, nil
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*errorx.TektonError)
+	return ok && e.Code == http.StatusNotFound
+}
+
+func isForbidden(err error) bool {
+	e, ok := err.(*errorx.TektonError)
+	return ok && e.Code == http.StatusForbidden
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DOT renders the graph in Graphviz format. Finally tasks are drawn as dashed boxes, implicit
+// result and when dependencies as dashed edges labelled with what they reference.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph pipeline {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		attrs := []string{fmt.Sprintf("label=%q", nodeLabel(n))}
+		if n.Finally {
+			attrs = append(attrs, "style=dashed")
+		}
+		if n.Layer < 0 {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", n.Name, strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		if e.Kind == EdgeRunAfter {
+			fmt.Fprintf(&b, "  %q -> %q;\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&b, "  %q -> %q [style=dashed, label=%q];\n", e.From, e.To, edgeLabel(e))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+var mermaidIDRegex = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		if n.Finally {
+			fmt.Fprintf(&b, "  %s([%q])\n", mermaidID(n.Name), nodeLabel(n))
+		} else {
+			fmt.Fprintf(&b, "  %s[%q]\n", mermaidID(n.Name), nodeLabel(n))
+		}
+	}
+	for _, e := range g.Edges {
+		if e.Kind == EdgeRunAfter {
+			fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(e.From), mermaidID(e.To))
+		} else {
+			fmt.Fprintf(&b, "  %s -. %s .-> %s\n", mermaidID(e.From), edgeLabel(e), mermaidID(e.To))
+		}
+	}
+	return b.String()
+}
+
+func nodeLabel(n Node) string {
+	if n.Ref == "" || n.Ref == n.Name {
+		return n.Name
+	}
+	return fmt.Sprintf("%s (%s)", n.Name, n.Ref)
+}
+
+func edgeLabel(e Edge) string {
+	if e.Detail == "" {
+		return string(e.Kind)
+	}
+	return fmt.Sprintf("%s: %s", e.Kind, e.Detail)
+}
+
+// mermaidID maps a task name to a node id; task names are DNS labels, so only '-' needs replacing.
+func mermaidID(name string) string {
+	return "t_" + mermaidIDRegex.ReplaceAllString(name, "_")
+}
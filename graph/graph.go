@@ -0,0 +1,318 @@
+// Package graph builds the task DAG of a Tekton pipeline from runAfter, result references and
+// when expressions, and reports cycles, dangling references and topological layers.
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/samber/lo"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+type EdgeKind string
+
+const (
+	// EdgeRunAfter is an explicit ordering from runAfter.
+	EdgeRunAfter EdgeKind = "runAfter"
+	// EdgeResult is an implicit ordering from a $(tasks.x.results.y) reference in params or matrix.
+	EdgeResult EdgeKind = "result"
+	// EdgeWhen is an implicit ordering from a result or status reference in a when expression.
+	EdgeWhen EdgeKind = "when"
+)
+
+type Node struct {
+	Name string
+	// Ref is the name of the referenced Task, the resolver name for remote tasks, or "" for an embedded taskSpec
+	Ref     string
+	Finally bool
+	// Layer is the topological layer starting at 0, -1 when the task is part of a cycle
+	Layer int
+}
+
+type Edge struct {
+	From string
+	To   string
+	Kind EdgeKind
+	// Detail is the referenced result name for result and when edges
+	Detail string
+}
+
+// DanglingRef is a reference to a pipeline task that does not exist.
+type DanglingRef struct {
+	// Task is the referencing pipeline task, or "" for a pipeline result
+	Task string
+	Ref  string
+	Kind EdgeKind
+}
+
+type Graph struct {
+	Nodes    []Node
+	Edges    []Edge
+	Dangling []DanglingRef
+	// Cycles holds one cycle per group of mutually dependent tasks, as the task names along it
+	Cycles [][]string
+	// Layers groups the task names that can run in parallel; finally tasks form the last layer
+	Layers [][]string
+
+	index map[string]int
+}
+
+var statusRefRegex = regexp.MustCompile(`\$\(tasks\.([^.)\s]+)\.(status|reason)\)`)
+
+// Build analyses spec without contacting the cluster.
+func Build(spec *tektonv1.PipelineSpec) *Graph {
+	g := &Graph{index: make(map[string]int)}
+	for _, pt := range spec.Tasks {
+		g.addNode(pt, false)
+	}
+	for _, pt := range spec.Finally {
+		g.addNode(pt, true)
+	}
+	for i := range spec.Tasks {
+		g.addEdges(&spec.Tasks[i])
+	}
+	for i := range spec.Finally {
+		g.addEdges(&spec.Finally[i])
+	}
+	for _, result := range spec.Results {
+		expressions, _ := result.GetVarSubstitutionExpressions()
+		for _, ref := range tektonv1.NewResultRefs(expressions) {
+			if _, ok := g.index[ref.PipelineTask]; !ok {
+				g.Dangling = append(g.Dangling, DanglingRef{Ref: ref.PipelineTask, Kind: EdgeResult})
+			}
+		}
+	}
+	g.findCycles()
+	g.computeLayers()
+	return g
+}
+
+func (g *Graph) Node(name string) (Node, bool) {
+	i, ok := g.index[name]
+	if !ok {
+		return Node{}, false
+	}
+	return g.Nodes[i], true
+}
+
+// Parents returns the names of the tasks name directly depends on.
+func (g *Graph) Parents(name string) []string {
+	var parents []string
+	for _, e := range g.Edges {
+		if e.To == name && !lo.Contains(parents, e.From) {
+			parents = append(parents, e.From)
+		}
+	}
+	return parents
+}
+
+// Children returns the names of the tasks directly depending on name.
+func (g *Graph) Children(name string) []string {
+	var children []string
+	for _, e := range g.Edges {
+		if e.From == name && !lo.Contains(children, e.To) {
+			children = append(children, e.To)
+		}
+	}
+	return children
+}
+
+// Err reports cycles and dangling references, or nil when the graph is a valid DAG.
+func (g *Graph) Err() error {
+	var msgs []string
+	for _, cycle := range g.Cycles {
+		msgs = append(msgs, fmt.Sprintf("cycle %s", strings.Join(append(cycle, cycle[0]), " -> ")))
+	}
+	for _, d := range g.Dangling {
+		if d.Task == "" {
+			msgs = append(msgs, fmt.Sprintf("pipeline result references unknown task %s", d.Ref))
+		} else {
+			msgs = append(msgs, fmt.Sprintf("task %s references unknown task %s via %s", d.Task, d.Ref, d.Kind))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errorx.NewDefaultError("%s", strings.Join(msgs, "; "))
+}
+
+func (g *Graph) addNode(pt tektonv1.PipelineTask, finally bool) {
+	node := Node{Name: pt.Name, Finally: finally, Layer: -1}
+	if pt.TaskRef != nil {
+		node.Ref = pt.TaskRef.Name
+		if node.Ref == "" {
+			node.Ref = string(pt.TaskRef.Resolver)
+		}
+	}
+	g.index[pt.Name] = len(g.Nodes)
+	g.Nodes = append(g.Nodes, node)
+}
+
+func (g *Graph) addEdges(pt *tektonv1.PipelineTask) {
+	for _, from := range pt.RunAfter {
+		g.addEdge(Edge{From: from, To: pt.Name, Kind: EdgeRunAfter})
+	}
+	params := append(tektonv1.Params{}, pt.Params...)
+	if pt.Matrix != nil {
+		params = append(params, pt.Matrix.Params...)
+		for _, include := range pt.Matrix.Include {
+			params = append(params, include.Params...)
+		}
+	}
+	for _, p := range params {
+		expressions, _ := p.GetVarSubstitutionExpressions()
+		for _, ref := range tektonv1.NewResultRefs(expressions) {
+			g.addEdge(Edge{From: ref.PipelineTask, To: pt.Name, Kind: EdgeResult, Detail: ref.Result})
+		}
+	}
+	for i := range pt.When {
+		expressions, _ := pt.When[i].GetVarSubstitutionExpressions()
+		for _, ref := range tektonv1.NewResultRefs(expressions) {
+			g.addEdge(Edge{From: ref.PipelineTask, To: pt.Name, Kind: EdgeWhen, Detail: ref.Result})
+		}
+		for _, s := range append([]string{pt.When[i].Input, pt.When[i].CEL}, pt.When[i].Values...) {
+			for _, m := range statusRefRegex.FindAllStringSubmatch(s, -1) {
+				g.addEdge(Edge{From: m[1], To: pt.Name, Kind: EdgeWhen, Detail: m[2]})
+			}
+		}
+	}
+}
+
+func (g *Graph) addEdge(e Edge) {
+	if _, ok := g.index[e.From]; !ok {
+		g.Dangling = append(g.Dangling, DanglingRef{Task: e.To, Ref: e.From, Kind: e.Kind})
+		return
+	}
+	for _, existing := range g.Edges {
+		if existing == e {
+			return
+		}
+	}
+	g.Edges = append(g.Edges, e)
+}
+
+// findCycles runs Tarjan's strongly connected components algorithm; every component with more
+// than one task, or a task depending on itself, is a cycle.
+func (g *Graph) findCycles() {
+	var (
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		next    int
+		connect func(name string)
+	)
+	connect = func(name string) {
+		index[name], lowlink[name] = next, next
+		next++
+		stack = append(stack, name)
+		onStack[name] = true
+		for _, child := range g.Children(name) {
+			if _, visited := index[child]; !visited {
+				connect(child)
+				lowlink[name] = min(lowlink[name], lowlink[child])
+			} else if onStack[child] {
+				lowlink[name] = min(lowlink[name], index[child])
+			}
+		}
+		if lowlink[name] != index[name] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == name {
+				break
+			}
+		}
+		if len(component) > 1 || lo.Contains(g.Children(name), name) {
+			sort.Slice(component, func(i, j int) bool { return g.index[component[i]] < g.index[component[j]] })
+			g.Cycles = append(g.Cycles, g.shortestCycle(component))
+		}
+	}
+	for _, node := range g.Nodes {
+		if _, visited := index[node.Name]; !visited {
+			connect(node.Name)
+		}
+	}
+}
+
+// shortestCycle returns the shortest cycle through the first task of a strongly connected
+// component, found by a breadth-first search back to it along the edges inside the component.
+func (g *Graph) shortestCycle(component []string) []string {
+	start := component[0]
+	prev := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, child := range g.Children(name) {
+			if !lo.Contains(component, child) {
+				continue
+			}
+			if child == start {
+				cycle := []string{name}
+				for cycle[0] != start {
+					cycle = append([]string{prev[cycle[0]]}, cycle...)
+				}
+				return cycle
+			}
+			if _, seen := prev[child]; !seen {
+				prev[child] = name
+				queue = append(queue, child)
+			}
+		}
+	}
+	return component
+}
+
+// computeLayers applies Kahn's algorithm to the regular tasks, skipping tasks in or after a cycle.
+func (g *Graph) computeLayers() {
+	inDegree := make(map[string]int)
+	for _, e := range g.Edges {
+		if n, _ := g.Node(e.To); !n.Finally {
+			inDegree[e.To]++
+		}
+	}
+	var current []string
+	for _, n := range g.Nodes {
+		if !n.Finally && inDegree[n.Name] == 0 {
+			current = append(current, n.Name)
+		}
+	}
+	for layer := 0; len(current) > 0; layer++ {
+		g.Layers = append(g.Layers, current)
+		var next []string
+		for _, name := range current {
+			g.Nodes[g.index[name]].Layer = layer
+			for _, child := range g.Children(name) {
+				if n, _ := g.Node(child); n.Finally {
+					continue
+				}
+				inDegree[child]--
+				if inDegree[child] == 0 {
+					next = append(next, child)
+				}
+			}
+		}
+		sort.Slice(next, func(i, j int) bool { return g.index[next[i]] < g.index[next[j]] })
+		current = next
+	}
+	var finally []string
+	for i, n := range g.Nodes {
+		if n.Finally {
+			g.Nodes[i].Layer = len(g.Layers)
+			finally = append(finally, n.Name)
+		}
+	}
+	if len(finally) > 0 {
+		g.Layers = append(g.Layers, finally)
+	}
+}
@@ -0,0 +1,167 @@
+// Package manifest turns objects read from the API server into manifests that can be applied again,
+// and reads and writes multi-document YAML bundles of them.
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	uyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// Profile selects the fields removed from an object before it is written out.
+type Profile struct {
+	// Status removes the status
+	Status bool
+	// Metadata are the metadata fields removed, e.g. uid or managedFields
+	Metadata []string
+	// Annotations are the annotations removed, the annotations map is dropped when left empty
+	Annotations []string
+}
+
+var (
+	// ProfileApply leaves what is needed to create the object again in another namespace or
+	// cluster: the status, the server-owned metadata and the last-applied annotation are removed.
+	ProfileApply = Profile{
+		Status: true,
+		Metadata: []string{
+			"uid",
+			"resourceVersion",
+			"generation",
+			"creationTimestamp",
+			"deletionTimestamp",
+			"deletionGracePeriodSeconds",
+			"managedFields",
+			"selfLink",
+			"ownerReferences",
+		},
+		Annotations: []string{lastAppliedAnnotation},
+	}
+	// ProfileAudit keeps the object as stored, status, uid, owners and finalizers included, and
+	// only removes the managedFields and last-applied annotation bookkeeping.
+	ProfileAudit = Profile{
+		Metadata:    []string{"managedFields"},
+		Annotations: []string{lastAppliedAnnotation},
+	}
+	// ProfileDefault is the profile of GetYaml: the real metadata without the status.
+	ProfileDefault = Profile{
+		Status:      true,
+		Metadata:    []string{"managedFields"},
+		Annotations: []string{lastAppliedAnnotation},
+	}
+)
+
+// Strip removes the fields selected by p from obj.
+func (p Profile) Strip(obj *unstructured.Unstructured) {
+	if p.Status {
+		delete(obj.Object, "status")
+	}
+	metadata, ok := obj.Object["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, field := range p.Metadata {
+		delete(metadata, field)
+	}
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	for _, annotation := range p.Annotations {
+		delete(annotations, annotation)
+	}
+	if annotations != nil && len(annotations) == 0 {
+		delete(metadata, "annotations")
+	}
+}
+
+// Clean strips obj with ProfileApply, the same way for every kind, so that it can be created
+// again in another namespace or cluster.
+func Clean(obj *unstructured.Unstructured) {
+	ProfileApply.Strip(obj)
+}
+
+// Options are the options of Encode, the zero value writes YAML with ProfileDefault.
+type Options struct {
+	Format  Format
+	Profile *Profile
+}
+
+// Encode strips obj with the profile of opts and writes it in its format. Keys are sorted in both
+// formats, so encoding the same object twice gives the same bytes.
+func Encode(obj *unstructured.Unstructured, opts Options) ([]byte, error) {
+	profile := ProfileDefault
+	if opts.Profile != nil {
+		profile = *opts.Profile
+	}
+	profile.Strip(obj)
+	switch opts.Format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(obj.Object, "", "  ")
+		if err != nil {
+			return nil, errorx.NewDefaultError("cannot marshal %s/%s: %s", obj.GetKind(), obj.GetName(), err.Error())
+		}
+		return append(data, '\n'), nil
+	case FormatYAML, "":
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, errorx.NewDefaultError("cannot marshal %s/%s: %s", obj.GetKind(), obj.GetName(), err.Error())
+		}
+		return data, nil
+	default:
+		return nil, errorx.NewDefaultError("unsupported format %s", opts.Format)
+	}
+}
+
+// Marshal writes objs as they are as a multi-document YAML bundle, strip them first. Keys are
+// sorted, so exporting the same objects twice gives the same bundle.
+func Marshal(objs []*unstructured.Unstructured) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, obj := range objs {
+		data, err := Encode(obj, Options{Format: FormatYAML, Profile: &Profile{}})
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal reads the objects of a multi-document YAML or JSON bundle, skipping empty documents.
+func Unmarshal(bundle []byte) ([]*unstructured.Unstructured, error) {
+	d := uyaml.NewYAMLOrJSONDecoder(bytes.NewReader(bundle), 4096)
+	var objs []*unstructured.Unstructured
+	for i := 0; ; i++ {
+		obj := make(map[string]interface{})
+		err := d.Decode(&obj)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errorx.NewDefaultError("cannot decode document %d: %s", i, err.Error())
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		if u.GetKind() == "" || u.GetAPIVersion() == "" || strings.TrimSpace(u.GetName()) == "" {
+			return nil, errorx.NewDefaultError("document %d has no apiVersion, kind or metadata.name", i)
+		}
+		objs = append(objs, u)
+	}
+	return objs, nil
+}
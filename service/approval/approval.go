@@ -0,0 +1,291 @@
+// Package approval implements manual approval gates on top of the ApprovalTask custom task of
+// openshift-pipelines: it lists the approvals PipelineRuns wait for, records the decisions of
+// approvers on the ApprovalTask, and waits for the outcome the approval controller reconciles.
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/service/v1alpha1"
+	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/imroc/req/v3"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// AuditAnnotation holds the decisions taken on an ApprovalTask, a JSON list of AuditRecord.
+const AuditAnnotation = "approval.tekton-sdk-go/audit"
+
+// pollInterval is the interval Wait polls the ApprovalTask at.
+const pollInterval = 2 * time.Second
+
+// conflictRetries is the number of times Decide gets the ApprovalTask again when another approver
+// or the controller updated it in between.
+const conflictRetries = 5
+
+type Approval struct {
+	approvalTasks *v1alpha1.ApprovalTask
+	httpclient    *req.Client
+	namespace     string
+	token         string
+}
+
+func NewApproval(c *config.Config, namespace string, svcCtx *service.ServiceContext) *Approval {
+	token, err := svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		panic(err)
+	}
+	return &Approval{
+		approvalTasks: v1alpha1.NewApprovalTask(c, namespace, svcCtx),
+		httpclient:    c.Httpclient,
+		namespace:     namespace,
+		token:         token,
+	}
+}
+
+// Pending is an ApprovalTask waiting for decisions.
+type Pending struct {
+	Name string
+	// PipelineRun and PipelineTask are the run and pipeline task the approval gates, from the labels
+	// Tekton sets on the CustomRun
+	PipelineRun  string
+	PipelineTask string
+	Description  string
+	Required     int
+	// Approved are the users who approved so far
+	Approved []string
+	// Waiting are the users and groups that did not answer yet
+	Waiting   []string
+	StartTime *metav1.Time
+}
+
+// Decision is the answer of an approver.
+type Decision struct {
+	// Approver is the user answering, and Groups the groups it belongs to: the user answers for the
+	// approver of its name, or else as a member of the approver groups it belongs to
+	Approver string
+	Groups   []string
+	Message  string
+}
+
+type AuditRecord struct {
+	Approver string `json:"approver"`
+	// Group is set when the approver answered as a member of the group
+	Group   string      `json:"group,omitempty"`
+	Input   string      `json:"input"`
+	Message string      `json:"message,omitempty"`
+	Time    metav1.Time `json:"time"`
+}
+
+// Pending lists the ApprovalTasks of the namespace still waiting for decisions, oldest first.
+func (a *Approval) Pending(ctx context.Context, opts metav1.ListOptions) ([]Pending, error) {
+	tasks, err := service.ListAll[types.ApprovalTask](ctx, func() *req.Request {
+		return a.httpclient.Get(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks", a.namespace)).SetBearerAuthToken(a.token)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Pending
+	for _, task := range tasks {
+		if task.Status.State != "" && task.Status.State != types.ApprovalStatePending {
+			continue
+		}
+		status := computeStatus(&task)
+		p := Pending{
+			Name:         task.Name,
+			PipelineRun:  task.Labels["tekton.dev/pipelineRun"],
+			PipelineTask: task.Labels["tekton.dev/pipelineTask"],
+			Description:  task.Spec.Description,
+			Required:     task.Spec.NumberOfApprovalsRequired,
+			Approved:     status.Approvers,
+			StartTime:    task.Status.StartTime,
+		}
+		if p.StartTime == nil {
+			p.StartTime = task.CreationTimestamp.DeepCopy()
+		}
+		for _, approver := range task.Spec.Approvers {
+			if !answered(approver) {
+				p.Waiting = append(p.Waiting, approver.Name)
+			}
+		}
+		res = append(res, p)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].StartTime.Before(res[j].StartTime) })
+	return res, nil
+}
+
+// Approve records the approval of d.Approver on the ApprovalTask, see Decide.
+func (a *Approval) Approve(ctx context.Context, name string, d Decision) (types.ApprovalTask, error) {
+	return a.Decide(ctx, name, types.ApprovalInputApprove, d)
+}
+
+// Reject records the rejection of d.Approver on the ApprovalTask, see Decide.
+func (a *Approval) Reject(ctx context.Context, name string, d Decision) (types.ApprovalTask, error) {
+	return a.Decide(ctx, name, types.ApprovalInputReject, d)
+}
+
+// Decide sets the input of d.Approver on the ApprovalTask like the approval gate CLI does and
+// appends it to the AuditAnnotation; the approval controller then updates the status of the
+// ApprovalTask and completes its CustomRun, see Wait. A user who is not an approver gets a 403
+// error, a decided ApprovalTask a 409 error. Decisions of approvers answering at the same time are
+// applied one after the other.
+func (a *Approval) Decide(ctx context.Context, name, input string, d Decision) (resp types.ApprovalTask, err error) {
+	for i := 0; i < conflictRetries; i++ {
+		var task types.ApprovalTask
+		if task, err = a.approvalTasks.Get(ctx, name); err != nil {
+			return
+		}
+		if decided(task.Status.State) || decided(computeStatus(&task).State) {
+			return resp, errorx.NewError(http.StatusConflict, fmt.Sprintf("approvaltask %s is already %s", name, task.Status.State), nil)
+		}
+		record, ok := answer(&task, input, d)
+		if !ok {
+			return resp, errorx.NewError(http.StatusForbidden, fmt.Sprintf("%s is not an approver of approvaltask %s", d.Approver, name), nil)
+		}
+		var audit []AuditRecord
+		if raw := task.Annotations[AuditAnnotation]; raw != "" {
+			if err = json.Unmarshal([]byte(raw), &audit); err != nil {
+				return resp, errorx.NewDefaultError("approvaltask %s has an invalid %s annotation: %s", name, AuditAnnotation, err.Error())
+			}
+		}
+		raw, _ := json.Marshal(append(audit, record))
+		if task.Annotations == nil {
+			task.Annotations = make(map[string]string)
+		}
+		task.Annotations[AuditAnnotation] = string(raw)
+		resp, err = a.approvalTasks.Update(ctx, &task)
+		if !isConflict(err) {
+			break
+		}
+	}
+	if isConflict(err) {
+		return resp, errorx.NewDefaultError("approvaltask %s kept changing, %d updates conflicted", name, conflictRetries)
+	}
+	return
+}
+
+// Wait polls the ApprovalTask until it is approved or rejected and returns it, or returns an error
+// when ctx is done first; bound the wait with a context deadline. An ApprovalTask not found is
+// polled for, any other error of Get is returned at once.
+func (a *Approval) Wait(ctx context.Context, name string) (task types.ApprovalTask, err error) {
+	var getErr error
+	err = wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		task, getErr = a.approvalTasks.Get(ctx, name)
+		if e, ok := getErr.(*errorx.TektonError); ok && e.Code == http.StatusNotFound {
+			// the controller may not have created the ApprovalTask yet, keep polling
+			return false, nil
+		}
+		if getErr != nil {
+			return false, getErr
+		}
+		return decided(task.Status.State), nil
+	})
+	if err != nil && getErr != nil {
+		err = getErr
+	}
+	return
+}
+
+// answer sets input on the approver entry of d.Approver, or on its member entry in the first
+// approver group it belongs to.
+func answer(task *types.ApprovalTask, input string, d Decision) (AuditRecord, bool) {
+	record := AuditRecord{Approver: d.Approver, Input: input, Message: d.Message, Time: metav1.Now()}
+	for i := range task.Spec.Approvers {
+		approver := &task.Spec.Approvers[i]
+		if approver.Type != types.ApproverTypeGroup && approver.Name == d.Approver {
+			approver.Input, approver.Message = input, d.Message
+			return record, true
+		}
+	}
+	for i := range task.Spec.Approvers {
+		approver := &task.Spec.Approvers[i]
+		if approver.Type != types.ApproverTypeGroup || !lo.Contains(d.Groups, approver.Name) {
+			continue
+		}
+		record.Group = approver.Name
+		approver.Message = d.Message
+		for j := range approver.Users {
+			if approver.Users[j].Name == d.Approver {
+				approver.Users[j].Input = input
+				return record, true
+			}
+		}
+		approver.Users = append(approver.Users, types.UserDetails{Name: d.Approver, Input: input})
+		return record, true
+	}
+	return record, false
+}
+
+func decided(state string) bool {
+	return state == types.ApprovalStateApproved || state == types.ApprovalStateRejected
+}
+
+func isConflict(err error) bool {
+	e, ok := err.(*errorx.TektonError)
+	return ok && e.Code == http.StatusConflict
+}
+
+func answered(approver types.ApproverDetails) bool {
+	if approver.Type == types.ApproverTypeGroup {
+		return lo.SomeBy(approver.Users, func(u types.UserDetails) bool { return u.Input != types.ApprovalInputPending })
+	}
+	return approver.Input == types.ApprovalInputApprove || approver.Input == types.ApprovalInputReject
+}
+
+// computeStatus derives the status of task from the inputs of its approvers like the approval gate
+// controller does: a rejection rejects it, NumberOfApprovalsRequired distinct users approve it.
+func computeStatus(task *types.ApprovalTask) types.ApprovalTaskStatus {
+	status := types.ApprovalTaskStatus{State: types.ApprovalStatePending, StartTime: task.Status.StartTime}
+	if status.StartTime == nil {
+		status.StartTime = task.CreationTimestamp.DeepCopy()
+	}
+	rejected := false
+	for _, approver := range task.Spec.Approvers {
+		state := types.ApproverState{Name: approver.Name, Type: approver.Type, Message: approver.Message}
+		if approver.Type == types.ApproverTypeGroup {
+			for _, u := range approver.Users {
+				switch u.Input {
+				case types.ApprovalInputApprove:
+					state.GroupMembers = append(state.GroupMembers, types.GroupMemberState{Name: u.Name, Response: types.ApprovalStateApproved})
+					status.Approvers = append(status.Approvers, u.Name)
+				case types.ApprovalInputReject:
+					state.GroupMembers = append(state.GroupMembers, types.GroupMemberState{Name: u.Name, Response: types.ApprovalStateRejected})
+					state.Response = types.ApprovalStateRejected
+				}
+			}
+			if state.Response == "" && len(state.GroupMembers) > 0 {
+				state.Response = types.ApprovalStateApproved
+			}
+		} else {
+			switch approver.Input {
+			case types.ApprovalInputApprove:
+				state.Response = types.ApprovalStateApproved
+				status.Approvers = append(status.Approvers, approver.Name)
+			case types.ApprovalInputReject:
+				state.Response = types.ApprovalStateRejected
+			}
+		}
+		if state.Response == "" {
+			continue
+		}
+		rejected = rejected || state.Response == types.ApprovalStateRejected
+		status.ApproversResponse = append(status.ApproversResponse, state)
+	}
+	status.Approvers = lo.Uniq(status.Approvers)
+	switch {
+	case rejected:
+		status.State = types.ApprovalStateRejected
+	case len(status.Approvers) >= max(task.Spec.NumberOfApprovalsRequired, 1):
+		status.State = types.ApprovalStateApproved
+	}
+	return status
+}
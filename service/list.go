@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imroc/req/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultPageSize is the page size of ListAll when opts.Limit is not set.
+const defaultPageSize = 500
+
+// ListAll lists a whole collection, following the continue token of the API server page after
+// page; opts.Limit is the page size. newRequest returns a new GET request on the collection for
+// every page.
+func ListAll[T any](ctx context.Context, newRequest func() *req.Request, opts metav1.ListOptions) ([]T, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	var items []T
+	next := opts.Continue
+	for {
+		r := newRequest().SetQueryParam("limit", fmt.Sprintf("%d", limit))
+		if opts.LabelSelector != "" {
+			r.SetQueryParam("labelSelector", opts.LabelSelector)
+		}
+		if opts.FieldSelector != "" {
+			r.SetQueryParam("fieldSelector", opts.FieldSelector)
+		}
+		if next != "" {
+			r.SetQueryParam("continue", next)
+		}
+		var page struct {
+			Metadata metav1.ListMeta `json:"metadata"`
+			Items    []T             `json:"items"`
+		}
+		if err := r.SetSuccessResult(&page).Do(ctx).Err; err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if next = page.Metadata.Continue; next == "" {
+			return items, nil
+		}
+	}
+}
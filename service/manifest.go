@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/imroc/req/v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GetManifest sends the GET request r of a single object and encodes the object with opts. The
+// object is decoded as a plain map, so every field the server returns reaches the profile.
+func GetManifest(ctx context.Context, r *req.Request, opts manifest.Options) (string, error) {
+	obj := make(map[string]interface{})
+	if err := r.SetSuccessResult(&obj).Do(ctx).Err; err != nil {
+		return "", err
+	}
+	data, err := manifest.Encode(&unstructured.Unstructured{Object: obj}, opts)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
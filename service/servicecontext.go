@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/imroc/req/v3"
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -137,3 +138,18 @@ func (s *ServiceContext) gtGVR(gvk schema.GroupVersionKind) (schema.GroupVersion
 
 	return mapping.Resource, nil
 }
+
+// DeleteWithOptions sends the DELETE request r, with opts as its body when given, which is how the
+// API server takes the propagation policy, grace period, preconditions and dry-run of a delete. A
+// delete takes a single DeleteOptions, more are rejected.
+func DeleteWithOptions(ctx context.Context, r *req.Request, opts ...metav1.DeleteOptions) error {
+	if len(opts) > 1 {
+		return errorx.NewDefaultError("a delete takes a single DeleteOptions, got %d", len(opts))
+	}
+	if len(opts) > 0 {
+		body := opts[0]
+		body.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "DeleteOptions"}
+		r.SetBody(&body)
+	}
+	return r.Do(ctx).Err
+}
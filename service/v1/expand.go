@@ -0,0 +1,336 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hongyuxuan/tekton-sdk-go/bundle"
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	"sigs.k8s.io/yaml"
+)
+
+// Fetcher fetches the object a resolver reference points to, e.g. from a local checkout of the git
+// repository the git resolver would clone. It returns the YAML or JSON of the object and its source.
+type Fetcher interface {
+	Fetch(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error)
+}
+
+// FetcherFunc is a function used as a Fetcher.
+type FetcherFunc func(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error)
+
+func (f FetcherFunc) Fetch(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error) {
+	return f(ctx, resolver, params)
+}
+
+// GitDirFetcher fetches the pathInRepo param of a git resolver reference from dir, a checkout of
+// the repository; the revision param is not checked out. A path leaving dir is rejected.
+func GitDirFetcher(dir string) Fetcher {
+	return FetcherFunc(func(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error) {
+		values := make(map[string]string)
+		for _, p := range params {
+			values[p.Name] = p.Value.StringVal
+		}
+		path := values["pathInRepo"]
+		if path == "" {
+			return nil, nil, errorx.NewDefaultError("missing pathInRepo param")
+		}
+		if !filepath.IsLocal(filepath.FromSlash(path)) {
+			return nil, nil, errorx.NewDefaultError("pathInRepo %s is not a path inside the repository", path)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			return nil, nil, errorx.NewDefaultError("cannot read %s: %s", path, err.Error())
+		}
+		return data, &tektonv1.RefSource{URI: values["url"], EntryPoint: path}, nil
+	})
+}
+
+// BundleFetcher fetches the object a bundles resolver reference points to, the kind (task when
+// empty) and name params of the bundle param pulled from its registry.
+func BundleFetcher(opts ...bundle.Options) Fetcher {
+	return FetcherFunc(func(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error) {
+		values := map[string]string{"kind": "task"}
+		for _, p := range params {
+			values[p.Name] = p.Value.StringVal
+		}
+		if values["bundle"] == "" || values["name"] == "" {
+			return nil, nil, errorx.NewDefaultError("missing bundle or name param")
+		}
+		img, err := bundle.Pull(ctx, values["bundle"], opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj, err := bundle.Get(img, values["kind"], values["name"])
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, nil, errorx.NewDefaultError("cannot marshal %s %s: %s", values["kind"], values["name"], err.Error())
+		}
+		digest, err := img.Digest()
+		if err != nil {
+			return nil, nil, errorx.NewDefaultError("cannot read digest of bundle %s: %s", values["bundle"], err.Error())
+		}
+		uri := values["bundle"]
+		if i := strings.LastIndex(uri, "@"); i >= 0 {
+			uri = uri[:i]
+		} else if i = strings.LastIndex(uri, ":"); i > strings.LastIndex(uri, "/") {
+			uri = uri[:i]
+		}
+		return data, &tektonv1.RefSource{
+			URI:        uri,
+			Digest:     map[string]string{digest.Algorithm: digest.Hex},
+			EntryPoint: values["name"],
+		}, nil
+	})
+}
+
+// ExpandOptions sets the fetchers of the resolvers by name, e.g. git, bundles or hub.
+type ExpandOptions struct {
+	Fetchers map[string]Fetcher
+}
+
+// ExpandedPipeline is a Pipeline with its Task and StepAction references inlined.
+type ExpandedPipeline struct {
+	Pipeline tektonv1.Pipeline
+	// Spec is the spec of the Pipeline with every resolved taskRef replaced by a taskSpec, and every
+	// resolved step ref by the StepAction with its params substituted
+	Spec       tektonv1.PipelineSpec
+	Provenance []Provenance
+	// Unresolved are the paths of the references kept as is: resolvers without a fetcher and custom tasks
+	Unresolved []string
+}
+
+// Provenance is where an inlined piece of an ExpandedPipeline comes from.
+type Provenance struct {
+	// Path is the inlined field, e.g. spec.tasks[0].taskSpec or spec.finally[0].taskSpec.steps[1]
+	Path string
+	Kind string
+	Name string
+	// Resolver is empty for an object of the namespace, else the resolver and its params
+	Resolver string
+	Params   tektonv1.Params
+	// Source is the source the fetcher reported for a resolver reference
+	Source *tektonv1.RefSource
+}
+
+// Expand returns the Pipeline with its taskRefs and step refs inlined: references by name are
+// fetched from the namespace of the service, resolver references with the fetcher of the resolver
+// in opts.
+//
+//	res, err := client.Pipeline("default").Expand(ctx, "build", v1.ExpandOptions{
+//		Fetchers: map[string]v1.Fetcher{"git": v1.GitDirFetcher("/src/catalog")},
+//	})
+func (t *Pipeline) Expand(ctx context.Context, name string, opts ...ExpandOptions) (*ExpandedPipeline, error) {
+	var opt ExpandOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	pipeline, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	e := &expander{pipeline: t, opts: opt, res: &ExpandedPipeline{Pipeline: pipeline}}
+	spec := pipeline.Spec.DeepCopy()
+	if err = e.expandTasks(ctx, "spec.tasks", spec.Tasks); err != nil {
+		return nil, err
+	}
+	if err = e.expandTasks(ctx, "spec.finally", spec.Finally); err != nil {
+		return nil, err
+	}
+	e.res.Spec = *spec
+	return e.res, nil
+}
+
+type expander struct {
+	pipeline *Pipeline
+	opts     ExpandOptions
+	res      *ExpandedPipeline
+}
+
+func (e *expander) expandTasks(ctx context.Context, path string, tasks []tektonv1.PipelineTask) error {
+	for i := range tasks {
+		pt := &tasks[i]
+		taskPath := fmt.Sprintf("%s[%d]", path, i)
+		if pt.TaskRef != nil {
+			ref := pt.TaskRef
+			if ref.APIVersion != "" || (ref.Kind != "" && ref.Kind != tektonv1.NamespacedTaskKind) {
+				e.res.Unresolved = append(e.res.Unresolved, taskPath+".taskRef")
+				continue
+			}
+			prov := Provenance{Path: taskPath + ".taskSpec", Kind: "Task", Name: ref.Name, Resolver: string(ref.Resolver), Params: ref.Params}
+			var task tektonv1.Task
+			ok, err := e.fetch(ctx, taskPath+".taskRef", &prov, func() (err error) {
+				task, err = NewTask(e.pipeline.config, e.pipeline.namespace, e.pipeline.svcCtx).Get(ctx, ref.Name)
+				return
+			}, func(apiVersion string, data []byte) error {
+				switch apiVersion {
+				case tektonv1.SchemeGroupVersion.String():
+					return yaml.Unmarshal(data, &task)
+				case pipelinev1beta1.SchemeGroupVersion.String():
+					var old pipelinev1beta1.Task
+					if err := yaml.Unmarshal(data, &old); err != nil {
+						return err
+					}
+					return old.ConvertTo(ctx, &task)
+				}
+				return errorx.NewDefaultError("unsupported apiVersion %s", apiVersion)
+			})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if prov.Name == "" {
+				prov.Name = task.Name
+			}
+			pt.TaskRef = nil
+			pt.TaskSpec = &tektonv1.EmbeddedTask{TaskSpec: task.Spec}
+			e.res.Provenance = append(e.res.Provenance, prov)
+		}
+		if pt.TaskSpec == nil {
+			continue
+		}
+		if err := e.expandSteps(ctx, taskPath+".taskSpec.steps", pt.TaskSpec.Steps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *expander) expandSteps(ctx context.Context, path string, steps []tektonv1.Step) error {
+	for i := range steps {
+		step := &steps[i]
+		if step.Ref == nil {
+			continue
+		}
+		stepPath := fmt.Sprintf("%s[%d]", path, i)
+		ref := step.Ref
+		prov := Provenance{Path: stepPath, Kind: "StepAction", Name: ref.Name, Resolver: string(ref.Resolver), Params: ref.Params}
+		var action pipelinev1beta1.StepAction
+		ok, err := e.fetch(ctx, stepPath+".ref", &prov, func() error {
+			return e.pipeline.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions/%s", e.pipeline.namespace, ref.Name)).
+				SetBearerAuthToken(e.pipeline.token).
+				SetSuccessResult(&action).
+				Do(ctx).Err
+		}, func(apiVersion string, data []byte) error {
+			switch apiVersion {
+			case pipelinev1beta1.SchemeGroupVersion.String():
+				return yaml.Unmarshal(data, &action)
+			case pipelinev1alpha1.SchemeGroupVersion.String():
+				var old pipelinev1alpha1.StepAction
+				if err := yaml.Unmarshal(data, &old); err != nil {
+					return err
+				}
+				return old.ConvertTo(ctx, &action)
+			}
+			return errorx.NewDefaultError("unsupported apiVersion %s", apiVersion)
+		})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if prov.Name == "" {
+			prov.Name = action.Name
+		}
+		*step = inlineStepAction(step, &action.Spec)
+		e.res.Provenance = append(e.res.Provenance, prov)
+	}
+	return nil
+}
+
+// fetch gets the object of a reference, from the namespace with get or from the fetcher of the
+// resolver of prov, decoded by the apiVersion it declares with decode. It returns false for a
+// resolver without fetcher, recorded as unresolved.
+func (e *expander) fetch(ctx context.Context, refPath string, prov *Provenance, get func() error, decode func(apiVersion string, data []byte) error) (bool, error) {
+	if prov.Resolver == "" {
+		if err := get(); err != nil {
+			return false, errorx.NewDefaultError("%s: cannot get %s %s: %s", refPath, prov.Kind, prov.Name, err.Error())
+		}
+		return true, nil
+	}
+	fetcher, ok := e.opts.Fetchers[prov.Resolver]
+	if !ok {
+		e.res.Unresolved = append(e.res.Unresolved, refPath)
+		return false, nil
+	}
+	data, source, err := fetcher.Fetch(ctx, prov.Resolver, prov.Params)
+	if err != nil {
+		return false, errorx.NewDefaultError("%s: %s resolver: %s", refPath, prov.Resolver, err.Error())
+	}
+	var typeMeta struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err = yaml.Unmarshal(data, &typeMeta); err != nil {
+		return false, errorx.NewDefaultError("%s: %s resolver returned an invalid manifest: %s", refPath, prov.Resolver, err.Error())
+	}
+	if typeMeta.Kind != prov.Kind {
+		return false, errorx.NewDefaultError("%s: %s resolver returned a %s, not a %s", refPath, prov.Resolver, typeMeta.Kind, prov.Kind)
+	}
+	if err = decode(typeMeta.APIVersion, data); err != nil {
+		return false, errorx.NewDefaultError("%s: cannot decode %s %s: %s", refPath, typeMeta.APIVersion, prov.Kind, err.Error())
+	}
+	prov.Source = source
+	return true, nil
+}
+
+// inlineStepAction returns the step running action, with the name and the execution settings of
+// step and the params of action substituted with the values of step or their defaults.
+func inlineStepAction(step *tektonv1.Step, action *pipelinev1beta1.StepActionSpec) tektonv1.Step {
+	res := action.DeepCopy().ToStep()
+	res.Name = step.Name
+	res.OnError = step.OnError
+	res.Timeout = step.Timeout
+	res.ComputeResources = step.ComputeResources
+	res.Workspaces = step.Workspaces
+	res.When = step.When
+	res.StdoutConfig, res.StderrConfig = step.StdoutConfig, step.StderrConfig
+
+	values, arrays := make(map[string]string), make(map[string][]string)
+	set := func(name string, v tektonv1.ParamValue) {
+		for _, key := range []string{"params.%s", "params[%q]", "params['%s']"} {
+			key = fmt.Sprintf(key, name)
+			switch v.Type {
+			case tektonv1.ParamTypeArray:
+				arrays[key] = v.ArrayVal
+			case tektonv1.ParamTypeString:
+				values[key] = v.StringVal
+			}
+		}
+	}
+	for _, p := range action.Params {
+		if p.Default != nil {
+			set(p.Name, *p.Default)
+		}
+	}
+	for _, p := range step.Params {
+		set(p.Name, p.Value)
+	}
+	res.Image = substitution.ApplyReplacements(res.Image, values)
+	res.Script = substitution.ApplyReplacements(res.Script, values)
+	res.WorkingDir = substitution.ApplyReplacements(res.WorkingDir, values)
+	var command, args []string
+	for _, c := range res.Command {
+		command = append(command, substitution.ApplyArrayReplacements(c, values, arrays)...)
+	}
+	for _, a := range res.Args {
+		args = append(args, substitution.ApplyArrayReplacements(a, values, arrays)...)
+	}
+	res.Command, res.Args = command, args
+	for i := range res.Env {
+		res.Env[i].Value = substitution.ApplyReplacements(res.Env[i].Value, values)
+	}
+	return *res
+}
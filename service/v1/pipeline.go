@@ -5,12 +5,14 @@ import (
 	"fmt"
 
 	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/graph"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
 	"github.com/hongyuxuan/tekton-sdk-go/service"
-	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
 	"github.com/imroc/req/v3"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 type Pipeline struct {
@@ -71,27 +73,68 @@ func (t *Pipeline) Get(ctx context.Context, name string) (resp tektonv1.Pipeline
 	return
 }
 
+// GetYaml returns the YAML manifest of the Pipeline with its metadata but without its status, see manifest.ProfileDefault.
 func (t *Pipeline) GetYaml(ctx context.Context, name string) (string, error) {
-	var task types.TektonResource
-	if err := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelines/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		SetSuccessResult(&task).Do(ctx).Err; err != nil {
-		return "", err
-	}
-	delete(task.Metadata.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
-	task.Status = nil
-	manifest, _ := yaml.Marshal(task)
-	return string(manifest), nil
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the Pipeline in the format and with the fields stripped by opts.
+func (t *Pipeline) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelines/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the Pipeline; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *Pipeline) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelines/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
 }
 
-func (t *Pipeline) Delete(ctx context.Context, name string) (err error) {
-	return t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelines/%s", t.namespace, name)).SetBearerAuthToken(t.token).Do(ctx).Err
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1/namespaces/default/pipelines?labelSelector=app%3Dtestpipeline
+func (t *Pipeline) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelines", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
 }
 
 func (t *Pipeline) Create(ctx context.Context, yamlStr string) (err error) {
 	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "Pipeline")
 }
 
+// CreateObject creates pipeline in the namespace of the service and returns the object stored by the server.
+func (t *Pipeline) CreateObject(ctx context.Context, pipeline *tektonv1.Pipeline) (resp tektonv1.Pipeline, err error) {
+	obj := pipeline.DeepCopy()
+	obj.APIVersion, obj.Kind = "tekton.dev/v1", "Pipeline"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelines", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Graph fetches the pipeline and builds its task DAG, see graph.Build.
+func (t *Pipeline) Graph(ctx context.Context, name string) (*graph.Graph, error) {
+	pipeline, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return graph.Build(&pipeline.Spec), nil
+}
+
+// Validate runs the webhook defaulting and validation on pipeline locally, without calling the API server.
+func (t *Pipeline) Validate(ctx context.Context, pipeline *tektonv1.Pipeline) *apis.FieldError {
+	return validation.Validate(ctx, pipeline)
+}
+
 func (t *Pipeline) processItems(items []tektonv1.Pipeline) []tektonv1.Pipeline {
 	for i := range items {
 		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
 	"github.com/hongyuxuan/tekton-sdk-go/service"
-	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
 	"github.com/imroc/req/v3"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 type PipelineRun struct {
@@ -71,27 +72,60 @@ func (t *PipelineRun) Get(ctx context.Context, name string) (resp tektonv1.Pipel
 	return
 }
 
+// GetYaml returns the YAML manifest of the PipelineRun with its metadata but without its status, see manifest.ProfileDefault.
 func (t *PipelineRun) GetYaml(ctx context.Context, name string) (string, error) {
-	var task types.TektonResource
-	if err := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelineruns/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		SetSuccessResult(&task).Do(ctx).Err; err != nil {
-		return "", err
-	}
-	delete(task.Metadata.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
-	task.Status = nil
-	manifest, _ := yaml.Marshal(task)
-	return string(manifest), nil
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the PipelineRun in the format and with the fields stripped by opts.
+func (t *PipelineRun) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelineruns/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
 }
 
-func (t *PipelineRun) Delete(ctx context.Context, name string) (err error) {
-	return t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelineruns/%s", t.namespace, name)).SetBearerAuthToken(t.token).Do(ctx).Err
+// Delete deletes the PipelineRun; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *PipelineRun) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelineruns/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1/namespaces/default/pipelineruns?labelSelector=app%3Dtestpipelinerun
+func (t *PipelineRun) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelineruns", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
 }
 
 func (t *PipelineRun) Create(ctx context.Context, yamlStr string) (err error) {
 	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "PipelineRun")
 }
 
+// CreateObject creates pipelineRun in the namespace of the service and returns the object stored by the server,
+// with the name generated from metadata.generateName when no name is set.
+func (t *PipelineRun) CreateObject(ctx context.Context, pipelineRun *tektonv1.PipelineRun) (resp tektonv1.PipelineRun, err error) {
+	obj := pipelineRun.DeepCopy()
+	obj.APIVersion, obj.Kind = "tekton.dev/v1", "PipelineRun"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelineruns", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on pipelineRun locally, without calling the API server.
+func (t *PipelineRun) Validate(ctx context.Context, pipelineRun *tektonv1.PipelineRun) *apis.FieldError {
+	return validation.Validate(ctx, pipelineRun)
+}
+
 func (t *PipelineRun) processItems(items []tektonv1.PipelineRun) []tektonv1.PipelineRun {
 	for i := range items {
 		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
@@ -0,0 +1,201 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/imroc/req/v3"
+	"github.com/samber/lo"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// Statuses accepted in PruneOptions.Statuses besides the raw condition reasons, e.g. Cancelled.
+const (
+	PruneStatusSucceeded = "Succeeded"
+	PruneStatusFailed    = "Failed"
+	PruneStatusRunning   = "Running"
+)
+
+type PruneOptions struct {
+	// LabelSelector restricts the runs considered for pruning
+	LabelSelector string
+	// KeepLast keeps the N most recent runs of every pipeline (or task for TaskRuns), whatever their status
+	KeepLast int
+	// MaxAge only prunes runs created longer ago than this, 0 means any age
+	MaxAge time.Duration
+	// Statuses only prunes runs in one of these statuses: Succeeded, Failed, Running, or a condition
+	// reason such as Cancelled or PipelineRunTimeout. Empty means every finished run; unfinished runs
+	// are only pruned when Running is listed.
+	Statuses []string
+	// DryRun reports the runs that would be deleted without deleting them
+	DryRun bool
+	// Concurrency bounds the number of parallel deletes, default 5
+	Concurrency int
+	// PropagationPolicy controls the deletion of the owned TaskRuns and pods, default Background
+	PropagationPolicy metav1.DeletionPropagation
+}
+
+type PruneResult struct {
+	DryRun bool
+	// Deleted are the runs deleted, or that would be deleted on a dry run, oldest first
+	Deleted []string
+	// Kept is the number of runs considered and not deleted
+	Kept int
+	// Errors holds the runs that could not be deleted
+	Errors map[string]error
+}
+
+type pruneCandidate struct {
+	name    string
+	group   string
+	created time.Time
+	status  duckv1.Status
+}
+
+// Prune deletes old PipelineRuns, considering every run of the namespace page by page. Runs are
+// grouped by their tekton.dev/pipeline label for KeepLast.
+func (t *PipelineRun) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, error) {
+	items, err := service.ListAll[tektonv1.PipelineRun](ctx, func() *req.Request {
+		return t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/pipelineruns", t.namespace)).SetBearerAuthToken(t.token)
+	}, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]pruneCandidate, 0, len(items))
+	for _, item := range items {
+		candidates = append(candidates, pruneCandidate{
+			name:    item.Name,
+			group:   item.Labels[pipeline.PipelineLabelKey],
+			created: item.CreationTimestamp.Time,
+			status:  item.Status.Status,
+		})
+	}
+	return prune(ctx, candidates, opts, func(ctx context.Context, name string, o metav1.DeleteOptions) error {
+		return t.Delete(ctx, name, o)
+	}), nil
+}
+
+// Prune deletes old standalone TaskRuns. TaskRuns created by a PipelineRun are left to be pruned
+// with it. Runs are grouped by their tekton.dev/task label for KeepLast.
+func (t *TaskRun) Prune(ctx context.Context, opts PruneOptions) (*PruneResult, error) {
+	items, err := service.ListAll[tektonv1.TaskRun](ctx, func() *req.Request {
+		return t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/taskruns", t.namespace)).SetBearerAuthToken(t.token)
+	}, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]pruneCandidate, 0, len(items))
+	for _, item := range items {
+		if _, ok := item.Labels[pipeline.PipelineRunLabelKey]; ok {
+			continue
+		}
+		candidates = append(candidates, pruneCandidate{
+			name:    item.Name,
+			group:   item.Labels[pipeline.TaskLabelKey],
+			created: item.CreationTimestamp.Time,
+			status:  item.Status.Status,
+		})
+	}
+	return prune(ctx, candidates, opts, func(ctx context.Context, name string, o metav1.DeleteOptions) error {
+		return t.Delete(ctx, name, o)
+	}), nil
+}
+
+func prune(ctx context.Context, candidates []pruneCandidate, opts PruneOptions, del func(context.Context, string, metav1.DeleteOptions) error) *PruneResult {
+	res := &PruneResult{DryRun: opts.DryRun, Errors: make(map[string]error)}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].created.After(candidates[j].created) })
+
+	var victims []string
+	seen := make(map[string]int)
+	now := time.Now()
+	for _, c := range candidates {
+		seen[c.group]++
+		if seen[c.group] <= opts.KeepLast ||
+			(opts.MaxAge > 0 && now.Sub(c.created) < opts.MaxAge) ||
+			!matchStatus(c.status, opts.Statuses) {
+			res.Kept++
+			continue
+		}
+		victims = append(victims, c.name)
+	}
+	// report the oldest first, the order a user reads a retention report in
+	victims = lo.Reverse(victims)
+	if opts.DryRun {
+		res.Deleted = victims
+		return res
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+	policy := opts.PropagationPolicy
+	if policy == "" {
+		policy = metav1.DeletePropagationBackground
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, name := range victims {
+		// once ctx is done no delete is started, the runs left are reported with its error
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			mu.Lock()
+			res.Errors[name] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := del(ctx, name, metav1.DeleteOptions{PropagationPolicy: &policy})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				res.Errors[name] = err
+			}
+		}(name)
+	}
+	wg.Wait()
+	for _, name := range victims {
+		if _, failed := res.Errors[name]; !failed {
+			res.Deleted = append(res.Deleted, name)
+		}
+	}
+	return res
+}
+
+func matchStatus(status duckv1.Status, statuses []string) bool {
+	cond := status.GetCondition(apis.ConditionSucceeded)
+	done := cond != nil && cond.Status != corev1.ConditionUnknown
+	if len(statuses) == 0 {
+		return done
+	}
+	for _, s := range statuses {
+		switch {
+		case s == PruneStatusSucceeded && done && cond.Status == corev1.ConditionTrue,
+			s == PruneStatusFailed && done && cond.Status == corev1.ConditionFalse,
+			s == PruneStatusRunning && !done,
+			cond != nil && s == cond.Reason:
+			return true
+		}
+	}
+	return false
+}
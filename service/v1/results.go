@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// Result is a PipelineRun or TaskRun result with its type.
+type Result struct {
+	Name  string
+	Type  tektonv1.ResultsType
+	Value tektonv1.ResultValue
+}
+
+func (r Result) String() string {
+	return r.Value.StringVal
+}
+
+func (r Result) Array() []string {
+	return r.Value.ArrayVal
+}
+
+func (r Result) Object() map[string]string {
+	return r.Value.ObjectVal
+}
+
+// Decode unmarshals the result into v: object results field by field, array results as a list,
+// and string results as the JSON document they contain.
+//
+//	var image struct {
+//		URL    string `json:"url"`
+//		Digest string `json:"digest"`
+//	}
+//	err := res.Decode(&image)
+func (r Result) Decode(v interface{}) error {
+	var data []byte
+	var err error
+	switch r.Type {
+	case tektonv1.ResultsTypeObject:
+		data, err = json.Marshal(r.Value.ObjectVal)
+	case tektonv1.ResultsTypeArray:
+		data, err = json.Marshal(r.Value.ArrayVal)
+	default:
+		data = []byte(r.Value.StringVal)
+	}
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return errorx.NewDefaultError("cannot decode %s result %s: %s", r.Type, r.Name, err.Error())
+	}
+	return nil
+}
+
+type Results []Result
+
+func (rs Results) Get(name string) (Result, bool) {
+	for _, r := range rs {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Result{}, false
+}
+
+type TaskResults struct {
+	PipelineTask string
+	TaskRun      string
+	Results      Results
+}
+
+type PipelineRunResults struct {
+	PipelineRun string
+	// Results are the results declared by the pipeline
+	Results Results
+	// Tasks are the results of every child TaskRun; a matrixed pipeline task has one entry per TaskRun
+	Tasks []TaskResults
+	// Missing are the child TaskRuns not found, e.g. pruned after the PipelineRun completed
+	Missing []string
+}
+
+// Task returns the results of the first TaskRun of pipelineTask.
+func (r *PipelineRunResults) Task(pipelineTask string) (Results, bool) {
+	for _, tr := range r.Tasks {
+		if tr.PipelineTask == pipelineTask {
+			return tr.Results, true
+		}
+	}
+	return nil, false
+}
+
+// Results returns the pipeline results and the results of every child TaskRun still found.
+func (t *PipelineRun) Results(ctx context.Context, name string) (*PipelineRunResults, error) {
+	pr, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	res := &PipelineRunResults{PipelineRun: pr.Name}
+	for _, r := range pr.Status.Results {
+		res.Results = append(res.Results, Result{Name: r.Name, Type: resultType(r.Value), Value: r.Value})
+	}
+	taskRuns := NewTaskRun(t.config, t.namespace, t.svcCtx)
+	for _, child := range pr.Status.ChildReferences {
+		if child.Kind != "TaskRun" {
+			continue
+		}
+		tr, err := taskRuns.Get(ctx, child.Name)
+		if e, ok := err.(*errorx.TektonError); ok && e.Code == http.StatusNotFound {
+			res.Missing = append(res.Missing, child.Name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.Tasks = append(res.Tasks, TaskResults{
+			PipelineTask: child.PipelineTaskName,
+			TaskRun:      child.Name,
+			Results:      taskRunResults(&tr),
+		})
+	}
+	return res, nil
+}
+
+// Results returns the results of the TaskRun.
+func (t *TaskRun) Results(ctx context.Context, name string) (Results, error) {
+	tr, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return taskRunResults(&tr), nil
+}
+
+func taskRunResults(tr *tektonv1.TaskRun) Results {
+	var res Results
+	for _, r := range tr.Status.Results {
+		typ := r.Type
+		if typ == "" {
+			typ = resultType(r.Value)
+		}
+		res = append(res, Result{Name: r.Name, Type: typ, Value: r.Value})
+	}
+	return res
+}
+
+func resultType(v tektonv1.ResultValue) tektonv1.ResultsType {
+	if v.Type == "" {
+		return tektonv1.ResultsTypeString
+	}
+	return tektonv1.ResultsType(v.Type)
+}
@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
 	"github.com/hongyuxuan/tekton-sdk-go/service"
-	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
 	"github.com/imroc/req/v3"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 type Task struct {
@@ -73,28 +74,59 @@ func (t *Task) Get(ctx context.Context, name string) (resp tektonv1.Task, err er
 	return
 }
 
+// GetYaml returns the YAML manifest of the Task with its metadata but without its status, see manifest.ProfileDefault.
 func (t *Task) GetYaml(ctx context.Context, name string) (string, error) {
-	var task types.TektonResource
-	if err := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/tasks/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		SetSuccessResult(&task).
-		Do(ctx).Err; err != nil {
-		return "", err
-	}
-	delete(task.Metadata.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
-	task.Status = nil
-	manifest, _ := yaml.Marshal(task)
-	return string(manifest), nil
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the Task in the format and with the fields stripped by opts.
+func (t *Task) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/tasks/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
 }
 
-func (t *Task) Delete(ctx context.Context, name string) (err error) {
-	return t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/tasks/%s", t.namespace, name)).SetBearerAuthToken(t.token).Do(ctx).Err
+// Delete deletes the Task; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *Task) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/tasks/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1/namespaces/default/tasks?labelSelector=app%3Dtesttask
+func (t *Task) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/tasks", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
 }
 
 func (t *Task) Create(ctx context.Context, yamlStr string) (err error) {
 	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "Task")
 }
 
+// CreateObject creates task in the namespace of the service and returns the object stored by the server.
+func (t *Task) CreateObject(ctx context.Context, task *tektonv1.Task) (resp tektonv1.Task, err error) {
+	obj := task.DeepCopy()
+	obj.APIVersion, obj.Kind = "tekton.dev/v1", "Task"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/tasks", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on task locally, without calling the API server.
+func (t *Task) Validate(ctx context.Context, task *tektonv1.Task) *apis.FieldError {
+	return validation.Validate(ctx, task)
+}
+
 func (t *Task) processItems(items []tektonv1.Task) []tektonv1.Task {
 	for i := range items {
 		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
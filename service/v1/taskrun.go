@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/imroc/req/v3"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type TaskRun struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	namespace  string
+	token      string
+}
+
+func NewTaskRun(c *config.Config, namespace string, svcCtx *service.ServiceContext) *TaskRun {
+	token, err := svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		panic(err)
+	}
+	return &TaskRun{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		namespace:  namespace,
+		token:      token,
+	}
+}
+
+type ListTaskRunResponse struct {
+	ApiVersion string             `json:"apiVersion"`
+	Items      []tektonv1.TaskRun `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1/namespaces/default/taskruns?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *TaskRun) List(ctx context.Context, opts metav1.ListOptions) (resp []tektonv1.TaskRun, err error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/taskruns", t.namespace)).SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListTaskRunResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1/namespaces/default/taskruns/:name
+func (t *TaskRun) Get(ctx context.Context, name string) (resp tektonv1.TaskRun, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/taskruns/%s", t.namespace, name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the TaskRun with its metadata but without its status, see manifest.ProfileDefault.
+func (t *TaskRun) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the TaskRun in the format and with the fields stripped by opts.
+func (t *TaskRun) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/taskruns/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the TaskRun; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *TaskRun) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/taskruns/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1/namespaces/default/taskruns?labelSelector=app%3Dtesttaskrun
+func (t *TaskRun) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/taskruns", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *TaskRun) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "TaskRun")
+}
+
+// CreateObject creates taskRun in the namespace of the service and returns the object stored by the server,
+// with the name generated from metadata.generateName when no name is set.
+func (t *TaskRun) CreateObject(ctx context.Context, taskRun *tektonv1.TaskRun) (resp tektonv1.TaskRun, err error) {
+	obj := taskRun.DeepCopy()
+	obj.APIVersion, obj.Kind = "tekton.dev/v1", "TaskRun"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/taskruns", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on taskRun locally, without calling the API server.
+func (t *TaskRun) Validate(ctx context.Context, taskRun *tektonv1.TaskRun) *apis.FieldError {
+	return validation.Validate(ctx, taskRun)
+}
+
+func (t *TaskRun) processItems(items []tektonv1.TaskRun) []tektonv1.TaskRun {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
@@ -0,0 +1,223 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/graph"
+	"github.com/samber/lo"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Timeline describes where the time of a PipelineRun went. Zero times mean "not yet".
+type Timeline struct {
+	PipelineRun    string
+	StartTime      time.Time
+	CompletionTime time.Time
+	Duration       time.Duration
+	// Tasks are the pipeline tasks that ran, ordered by start time
+	Tasks []TaskTiming
+	// CriticalPath is the chain of tasks, each gated by the previous one, that ends with the last
+	// task to complete; finally tasks are appended after the regular ones
+	CriticalPath         []string
+	CriticalPathDuration time.Duration
+	Skipped              []SkippedTask
+}
+
+type TaskTiming struct {
+	PipelineTask string
+	TaskRun      string
+	Pod          string
+	Finally      bool
+	// Status is the reason of the TaskRun's Succeeded condition, e.g. Succeeded, Failed, Running
+	Status    string
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	// QueueDuration is the time between the TaskRun starting and its pod being scheduled
+	QueueDuration time.Duration
+	Steps         []StepTiming
+}
+
+type StepTiming struct {
+	Name      string
+	Container string
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	ExitCode  int32
+	// Reason is the termination reason, e.g. Completed, Error, OOMKilled, or "Running"/"Waiting"
+	Reason string
+}
+
+type SkippedTask struct {
+	Name            string
+	Reason          string
+	WhenExpressions []tektonv1.WhenExpression
+}
+
+// Timeline joins the PipelineRun with its child TaskRuns and their pods.
+func (t *PipelineRun) Timeline(ctx context.Context, name string) (*Timeline, error) {
+	pr, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	selector := fmt.Sprintf("%s=%s", pipeline.PipelineRunLabelKey, name)
+	taskRuns, err := NewTaskRun(t.config, t.namespace, t.svcCtx).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := t.svcCtx.Clientset.CoreV1().Pods(t.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return NewTimeline(&pr, taskRuns, pods.Items), nil
+}
+
+// NewTimeline computes the timeline from objects already fetched, without calling the API server.
+func NewTimeline(pr *tektonv1.PipelineRun, taskRuns []tektonv1.TaskRun, pods []corev1.Pod) *Timeline {
+	tl := &Timeline{PipelineRun: pr.Name}
+	if pr.Status.StartTime != nil {
+		tl.StartTime = pr.Status.StartTime.Time
+	}
+	if pr.Status.CompletionTime != nil {
+		tl.CompletionTime = pr.Status.CompletionTime.Time
+		tl.Duration = tl.CompletionTime.Sub(tl.StartTime)
+	}
+
+	finally := make(map[string]bool)
+	if pr.Status.PipelineSpec != nil {
+		for _, pt := range pr.Status.PipelineSpec.Finally {
+			finally[pt.Name] = true
+		}
+	}
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+	}
+	for i := range taskRuns {
+		if !ownedBy(&taskRuns[i], pr) {
+			continue
+		}
+		tl.Tasks = append(tl.Tasks, newTaskTiming(&taskRuns[i], podsByName[taskRuns[i].Status.PodName], finally))
+	}
+	sort.SliceStable(tl.Tasks, func(i, j int) bool { return tl.Tasks[i].StartTime.Before(tl.Tasks[j].StartTime) })
+
+	for _, skipped := range pr.Status.SkippedTasks {
+		tl.Skipped = append(tl.Skipped, SkippedTask{
+			Name:            skipped.Name,
+			Reason:          string(skipped.Reason),
+			WhenExpressions: skipped.WhenExpressions,
+		})
+	}
+	if pr.Status.PipelineSpec != nil {
+		tl.criticalPath(graph.Build(pr.Status.PipelineSpec))
+	}
+	return tl
+}
+
+func ownedBy(tr *tektonv1.TaskRun, pr *tektonv1.PipelineRun) bool {
+	if tr.Labels[pipeline.PipelineRunLabelKey] == pr.Name {
+		return true
+	}
+	for _, ref := range tr.OwnerReferences {
+		if ref.Kind == "PipelineRun" && ref.Name == pr.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func newTaskTiming(tr *tektonv1.TaskRun, pod *corev1.Pod, finally map[string]bool) TaskTiming {
+	tt := TaskTiming{
+		PipelineTask: tr.Labels[pipeline.PipelineTaskLabelKey],
+		TaskRun:      tr.Name,
+		Pod:          tr.Status.PodName,
+	}
+	tt.Finally = finally[tt.PipelineTask]
+	if cond := tr.Status.GetCondition(apis.ConditionSucceeded); cond != nil {
+		tt.Status = cond.Reason
+	}
+	if tr.Status.StartTime != nil {
+		tt.StartTime = tr.Status.StartTime.Time
+	}
+	if tr.Status.CompletionTime != nil {
+		tt.EndTime = tr.Status.CompletionTime.Time
+		tt.Duration = tt.EndTime.Sub(tt.StartTime)
+	}
+	if pod != nil && !tt.StartTime.IsZero() {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionTrue {
+				tt.QueueDuration = cond.LastTransitionTime.Sub(tt.StartTime)
+				if tt.QueueDuration < 0 {
+					tt.QueueDuration = 0
+				}
+			}
+		}
+	}
+	for _, step := range tr.Status.Steps {
+		st := StepTiming{Name: step.Name, Container: step.Container}
+		switch {
+		case step.Terminated != nil:
+			st.StartTime = step.Terminated.StartedAt.Time
+			st.EndTime = step.Terminated.FinishedAt.Time
+			st.Duration = st.EndTime.Sub(st.StartTime)
+			st.ExitCode = step.Terminated.ExitCode
+			st.Reason = step.Terminated.Reason
+		case step.Running != nil:
+			st.StartTime = step.Running.StartedAt.Time
+			st.Reason = "Running"
+		case step.Waiting != nil:
+			st.Reason = "Waiting"
+		}
+		tt.Steps = append(tt.Steps, st)
+	}
+	return tt
+}
+
+// criticalPath walks back from the last regular task to complete, each time following the
+// parent that completed last, i.e. the dependency that actually gated the task. A matrixed task
+// completes with its last TaskRun.
+func (tl *Timeline) criticalPath(g *graph.Graph) {
+	timings := make(map[string]TaskTiming, len(tl.Tasks))
+	var last, lastFinally string
+	for _, tt := range tl.Tasks {
+		if prev, ok := timings[tt.PipelineTask]; tt.EndTime.IsZero() || ok && !tt.EndTime.After(prev.EndTime) {
+			continue
+		}
+		timings[tt.PipelineTask] = tt
+		if tt.Finally {
+			if lastFinally == "" || tt.EndTime.After(timings[lastFinally].EndTime) {
+				lastFinally = tt.PipelineTask
+			}
+		} else if last == "" || tt.EndTime.After(timings[last].EndTime) {
+			last = tt.PipelineTask
+		}
+	}
+	var path []string
+	for current := last; current != ""; {
+		path = append([]string{current}, path...)
+		next := ""
+		for _, parent := range g.Parents(current) {
+			pt, ok := timings[parent]
+			if ok && !lo.Contains(path, parent) && (next == "" || pt.EndTime.After(timings[next].EndTime)) {
+				next = parent
+			}
+		}
+		current = next
+	}
+	if lastFinally != "" {
+		path = append(path, lastFinally)
+	}
+	if len(path) == 0 {
+		return
+	}
+	tl.CriticalPath = path
+	tl.CriticalPathDuration = timings[path[len(path)-1]].EndTime.Sub(timings[path[0]].StartTime)
+}
@@ -0,0 +1,166 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/imroc/req/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ApprovalTask struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	namespace  string
+	token      string
+}
+
+func NewApprovalTask(c *config.Config, namespace string, svcCtx *service.ServiceContext) *ApprovalTask {
+	token, err := svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		panic(err)
+	}
+	return &ApprovalTask{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		namespace:  namespace,
+		token:      token,
+	}
+}
+
+type ListApprovalTaskResponse struct {
+	ApiVersion string               `json:"apiVersion"`
+	Items      []types.ApprovalTask `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *ApprovalTask) List(ctx context.Context, opts metav1.ListOptions) (resp []types.ApprovalTask, err error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks", t.namespace)).SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListApprovalTaskResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks?watch=true&labelSelector=app%3Dtestapprovaltask
+func (t *ApprovalTask) Watch(ctx context.Context, opts metav1.ListOptions) (<-chan service.WatchEvent[types.ApprovalTask], error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks", t.namespace)).SetBearerAuthToken(t.token)
+	return service.Watch[types.ApprovalTask](ctx, req, opts)
+}
+
+// https://apiserver.cluster.local:6443/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks/:name
+func (t *ApprovalTask) Get(ctx context.Context, name string) (resp types.ApprovalTask, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks/%s", t.namespace, name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the ApprovalTask with its metadata but without its status, see manifest.ProfileDefault.
+func (t *ApprovalTask) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the ApprovalTask in the format and with the fields stripped by opts.
+func (t *ApprovalTask) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the ApprovalTask; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *ApprovalTask) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks?labelSelector=app%3Dtestapprovaltask
+func (t *ApprovalTask) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *ApprovalTask) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "ApprovalTask")
+}
+
+// CreateObject creates approvalTask in the namespace of the service and returns the object stored by the server.
+func (t *ApprovalTask) CreateObject(ctx context.Context, approvalTask *types.ApprovalTask) (resp types.ApprovalTask, err error) {
+	obj := approvalTask.DeepCopy()
+	obj.APIVersion, obj.Kind = "openshift-pipelines.org/v1alpha1", "ApprovalTask"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Update replaces the ApprovalTask with approvalTask, whose resourceVersion makes the update fail
+// with 409 when it is stale; the status is ignored, see UpdateStatus.
+func (t *ApprovalTask) Update(ctx context.Context, approvalTask *types.ApprovalTask) (resp types.ApprovalTask, err error) {
+	obj := approvalTask.DeepCopy()
+	obj.APIVersion, obj.Kind = "openshift-pipelines.org/v1alpha1", "ApprovalTask"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Put(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks/%s", t.namespace, obj.Name)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// UpdateStatus replaces the status of the ApprovalTask with the status of approvalTask through the
+// status subresource.
+func (t *ApprovalTask) UpdateStatus(ctx context.Context, approvalTask *types.ApprovalTask) (resp types.ApprovalTask, err error) {
+	obj := approvalTask.DeepCopy()
+	obj.APIVersion, obj.Kind = "openshift-pipelines.org/v1alpha1", "ApprovalTask"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Put(fmt.Sprintf("/apis/openshift-pipelines.org/v1alpha1/namespaces/%s/approvaltasks/%s/status", t.namespace, obj.Name)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+func (t *ApprovalTask) processItems(items []types.ApprovalTask) []types.ApprovalTask {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
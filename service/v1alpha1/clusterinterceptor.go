@@ -0,0 +1,140 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/imroc/req/v3"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type ClusterInterceptor struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	token      string
+}
+
+// NewClusterInterceptor returns the service of the cluster-scoped ClusterInterceptors; with a secret prefix the token is
+// read from the default namespace.
+func NewClusterInterceptor(c *config.Config, svcCtx *service.ServiceContext) *ClusterInterceptor {
+	token, err := svcCtx.GetBearerToken(metav1.NamespaceDefault)
+	if err != nil {
+		panic(err)
+	}
+	return &ClusterInterceptor{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		token:      token,
+	}
+}
+
+type ListClusterInterceptorResponse struct {
+	ApiVersion string                              `json:"apiVersion"`
+	Items      []tektonv1alpha1.ClusterInterceptor `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *ClusterInterceptor) List(ctx context.Context, opts metav1.ListOptions) (resp []tektonv1alpha1.ClusterInterceptor, err error) {
+	req := t.httpclient.Get("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors").SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListClusterInterceptorResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors?watch=true&labelSelector=app%3Dtestclusterinterceptor
+func (t *ClusterInterceptor) Watch(ctx context.Context, opts metav1.ListOptions) (<-chan service.WatchEvent[tektonv1alpha1.ClusterInterceptor], error) {
+	req := t.httpclient.Get("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors").SetBearerAuthToken(t.token)
+	return service.Watch[tektonv1alpha1.ClusterInterceptor](ctx, req, opts)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors/:name
+func (t *ClusterInterceptor) Get(ctx context.Context, name string) (resp tektonv1alpha1.ClusterInterceptor, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors/%s", name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the ClusterInterceptor with its metadata but without its status, see manifest.ProfileDefault.
+func (t *ClusterInterceptor) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the ClusterInterceptor in the format and with the fields stripped by opts.
+func (t *ClusterInterceptor) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors/%s", name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the ClusterInterceptor; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *ClusterInterceptor) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors/%s", name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors?labelSelector=app%3Dtestclusterinterceptor
+func (t *ClusterInterceptor) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors").SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *ClusterInterceptor) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, "", yamlStr, "ClusterInterceptor")
+}
+
+// CreateObject creates clusterInterceptor and returns the object stored by the server.
+func (t *ClusterInterceptor) CreateObject(ctx context.Context, clusterInterceptor *tektonv1alpha1.ClusterInterceptor) (resp tektonv1alpha1.ClusterInterceptor, err error) {
+	obj := clusterInterceptor.DeepCopy()
+	obj.APIVersion, obj.Kind = "triggers.tekton.dev/v1alpha1", "ClusterInterceptor"
+	if err = t.httpclient.Post("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors").
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on clusterInterceptor locally, without calling the API server.
+func (t *ClusterInterceptor) Validate(ctx context.Context, clusterInterceptor *tektonv1alpha1.ClusterInterceptor) *apis.FieldError {
+	return validation.Validate(ctx, clusterInterceptor)
+}
+
+func (t *ClusterInterceptor) processItems(items []tektonv1alpha1.ClusterInterceptor) []tektonv1alpha1.ClusterInterceptor {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
@@ -0,0 +1,141 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/imroc/req/v3"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type Interceptor struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	namespace  string
+	token      string
+}
+
+func NewInterceptor(c *config.Config, namespace string, svcCtx *service.ServiceContext) *Interceptor {
+	token, err := svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		panic(err)
+	}
+	return &Interceptor{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		namespace:  namespace,
+		token:      token,
+	}
+}
+
+type ListInterceptorResponse struct {
+	ApiVersion string                       `json:"apiVersion"`
+	Items      []tektonv1alpha1.Interceptor `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/namespaces/default/interceptors?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *Interceptor) List(ctx context.Context, opts metav1.ListOptions) (resp []tektonv1alpha1.Interceptor, err error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/namespaces/%s/interceptors", t.namespace)).SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListInterceptorResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/namespaces/default/interceptors?watch=true&labelSelector=app%3Dtestinterceptor
+func (t *Interceptor) Watch(ctx context.Context, opts metav1.ListOptions) (<-chan service.WatchEvent[tektonv1alpha1.Interceptor], error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/namespaces/%s/interceptors", t.namespace)).SetBearerAuthToken(t.token)
+	return service.Watch[tektonv1alpha1.Interceptor](ctx, req, opts)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/namespaces/default/interceptors/:name
+func (t *Interceptor) Get(ctx context.Context, name string) (resp tektonv1alpha1.Interceptor, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/namespaces/%s/interceptors/%s", t.namespace, name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the Interceptor with its metadata but without its status, see manifest.ProfileDefault.
+func (t *Interceptor) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the Interceptor in the format and with the fields stripped by opts.
+func (t *Interceptor) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/namespaces/%s/interceptors/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the Interceptor; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *Interceptor) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/namespaces/%s/interceptors/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1alpha1/namespaces/default/interceptors?labelSelector=app%3Dtestinterceptor
+func (t *Interceptor) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/namespaces/%s/interceptors", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *Interceptor) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "Interceptor")
+}
+
+// CreateObject creates interceptor in the namespace of the service and returns the object stored by the server.
+func (t *Interceptor) CreateObject(ctx context.Context, interceptor *tektonv1alpha1.Interceptor) (resp tektonv1alpha1.Interceptor, err error) {
+	obj := interceptor.DeepCopy()
+	obj.APIVersion, obj.Kind = "triggers.tekton.dev/v1alpha1", "Interceptor"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/triggers.tekton.dev/v1alpha1/namespaces/%s/interceptors", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on interceptor locally, without calling the API server.
+func (t *Interceptor) Validate(ctx context.Context, interceptor *tektonv1alpha1.Interceptor) *apis.FieldError {
+	return validation.Validate(ctx, interceptor)
+}
+
+func (t *Interceptor) processItems(items []tektonv1alpha1.Interceptor) []tektonv1alpha1.Interceptor {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
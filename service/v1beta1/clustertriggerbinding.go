@@ -0,0 +1,140 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/imroc/req/v3"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type ClusterTriggerBinding struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	token      string
+}
+
+// NewClusterTriggerBinding returns the service of the cluster-scoped ClusterTriggerBindings; with a secret prefix the token is
+// read from the default namespace.
+func NewClusterTriggerBinding(c *config.Config, svcCtx *service.ServiceContext) *ClusterTriggerBinding {
+	token, err := svcCtx.GetBearerToken(metav1.NamespaceDefault)
+	if err != nil {
+		panic(err)
+	}
+	return &ClusterTriggerBinding{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		token:      token,
+	}
+}
+
+type ListClusterTriggerBindingResponse struct {
+	ApiVersion string                                `json:"apiVersion"`
+	Items      []tektonv1beta1.ClusterTriggerBinding `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *ClusterTriggerBinding) List(ctx context.Context, opts metav1.ListOptions) (resp []tektonv1beta1.ClusterTriggerBinding, err error) {
+	req := t.httpclient.Get("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings").SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListClusterTriggerBindingResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings?watch=true&labelSelector=app%3Dtestclustertriggerbinding
+func (t *ClusterTriggerBinding) Watch(ctx context.Context, opts metav1.ListOptions) (<-chan service.WatchEvent[tektonv1beta1.ClusterTriggerBinding], error) {
+	req := t.httpclient.Get("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings").SetBearerAuthToken(t.token)
+	return service.Watch[tektonv1beta1.ClusterTriggerBinding](ctx, req, opts)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings/:name
+func (t *ClusterTriggerBinding) Get(ctx context.Context, name string) (resp tektonv1beta1.ClusterTriggerBinding, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings/%s", name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the ClusterTriggerBinding with its metadata but without its status, see manifest.ProfileDefault.
+func (t *ClusterTriggerBinding) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the ClusterTriggerBinding in the format and with the fields stripped by opts.
+func (t *ClusterTriggerBinding) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings/%s", name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the ClusterTriggerBinding; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *ClusterTriggerBinding) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings/%s", name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings?labelSelector=app%3Dtestclustertriggerbinding
+func (t *ClusterTriggerBinding) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings").SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *ClusterTriggerBinding) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, "", yamlStr, "ClusterTriggerBinding")
+}
+
+// CreateObject creates clusterTriggerBinding and returns the object stored by the server.
+func (t *ClusterTriggerBinding) CreateObject(ctx context.Context, clusterTriggerBinding *tektonv1beta1.ClusterTriggerBinding) (resp tektonv1beta1.ClusterTriggerBinding, err error) {
+	obj := clusterTriggerBinding.DeepCopy()
+	obj.APIVersion, obj.Kind = "triggers.tekton.dev/v1beta1", "ClusterTriggerBinding"
+	if err = t.httpclient.Post("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings").
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on clusterTriggerBinding locally, without calling the API server.
+func (t *ClusterTriggerBinding) Validate(ctx context.Context, clusterTriggerBinding *tektonv1beta1.ClusterTriggerBinding) *apis.FieldError {
+	return validation.Validate(ctx, clusterTriggerBinding)
+}
+
+func (t *ClusterTriggerBinding) processItems(items []tektonv1beta1.ClusterTriggerBinding) []tektonv1beta1.ClusterTriggerBinding {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
@@ -0,0 +1,141 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/imroc/req/v3"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type CustomRun struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	namespace  string
+	token      string
+}
+
+func NewCustomRun(c *config.Config, namespace string, svcCtx *service.ServiceContext) *CustomRun {
+	token, err := svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		panic(err)
+	}
+	return &CustomRun{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		namespace:  namespace,
+		token:      token,
+	}
+}
+
+type ListCustomRunResponse struct {
+	ApiVersion string                      `json:"apiVersion"`
+	Items      []pipelinev1beta1.CustomRun `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/customruns?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *CustomRun) List(ctx context.Context, opts metav1.ListOptions) (resp []pipelinev1beta1.CustomRun, err error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns", t.namespace)).SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListCustomRunResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/customruns?watch=true&labelSelector=app%3Dtestcustomrun
+func (t *CustomRun) Watch(ctx context.Context, opts metav1.ListOptions) (<-chan service.WatchEvent[pipelinev1beta1.CustomRun], error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns", t.namespace)).SetBearerAuthToken(t.token)
+	return service.Watch[pipelinev1beta1.CustomRun](ctx, req, opts)
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/customruns/:name
+func (t *CustomRun) Get(ctx context.Context, name string) (resp pipelinev1beta1.CustomRun, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns/%s", t.namespace, name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the CustomRun with its metadata but without its status, see manifest.ProfileDefault.
+func (t *CustomRun) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the CustomRun in the format and with the fields stripped by opts.
+func (t *CustomRun) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the CustomRun; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *CustomRun) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/customruns?labelSelector=app%3Dtestcustomrun
+func (t *CustomRun) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *CustomRun) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "CustomRun")
+}
+
+// CreateObject creates customRun in the namespace of the service and returns the object stored by the server,
+// with the name generated from metadata.generateName when no name is set.
+func (t *CustomRun) CreateObject(ctx context.Context, customRun *pipelinev1beta1.CustomRun) (resp pipelinev1beta1.CustomRun, err error) {
+	obj := customRun.DeepCopy()
+	obj.APIVersion, obj.Kind = "tekton.dev/v1beta1", "CustomRun"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on customRun locally, without calling the API server.
+func (t *CustomRun) Validate(ctx context.Context, customRun *pipelinev1beta1.CustomRun) *apis.FieldError {
+	return validation.Validate(ctx, customRun)
+}
+
+func (t *CustomRun) processItems(items []pipelinev1beta1.CustomRun) []pipelinev1beta1.CustomRun {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
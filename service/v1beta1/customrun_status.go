@@ -0,0 +1,130 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// conflictRetries is how many times ModifyStatus gets the CustomRun again when its update conflicts.
+const conflictRetries = 5
+
+// Cancel asks the custom task controller to cancel the CustomRun: spec.status is set to
+// RunCancelled and spec.statusMessage to message, the controller then marks it failed.
+func (t *CustomRun) Cancel(ctx context.Context, name, message string) (resp pipelinev1beta1.CustomRun, err error) {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"status":        pipelinev1beta1.CustomRunSpecStatusCancelled,
+			"statusMessage": message,
+		},
+	}
+	if err = t.httpclient.Patch(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns/%s", t.namespace, name)).
+		SetBearerAuthToken(t.token).
+		SetBodyJsonMarshal(patch).
+		SetContentType("application/merge-patch+json").
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// UpdateStatus replaces the status of the CustomRun with the status of customRun through the status
+// subresource; the resourceVersion of customRun makes the update fail with 409 when it is stale.
+func (t *CustomRun) UpdateStatus(ctx context.Context, customRun *pipelinev1beta1.CustomRun) (resp pipelinev1beta1.CustomRun, err error) {
+	obj := customRun.DeepCopy()
+	obj.APIVersion, obj.Kind = "tekton.dev/v1beta1", "CustomRun"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Put(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/customruns/%s/status", t.namespace, obj.Name)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// ModifyStatus gets the CustomRun, applies modify to its status and updates the status subresource,
+// getting it again when another controller updated it in between.
+//
+//	run, err := client.CustomRun("default").ModifyStatus(ctx, "approval-x7k2p", func(s *pipelinev1beta1.CustomRunStatus) {
+//		s.ExtraFields = runtime.RawExtension{Raw: []byte(`{"approvedBy":"alice"}`)}
+//	})
+func (t *CustomRun) ModifyStatus(ctx context.Context, name string, modify func(status *pipelinev1beta1.CustomRunStatus)) (resp pipelinev1beta1.CustomRun, err error) {
+	for i := 0; i < conflictRetries; i++ {
+		var customRun pipelinev1beta1.CustomRun
+		if customRun, err = t.Get(ctx, name); err != nil {
+			return
+		}
+		modify(&customRun.Status)
+		resp, err = t.UpdateStatus(ctx, &customRun)
+		if e, ok := err.(*errorx.TektonError); !ok || e.Code != http.StatusConflict {
+			return
+		}
+	}
+	return
+}
+
+// SetCondition sets cond on the status of the CustomRun, replacing the condition of the same type.
+func (t *CustomRun) SetCondition(ctx context.Context, name string, cond apis.Condition) (pipelinev1beta1.CustomRun, error) {
+	return t.ModifyStatus(ctx, name, func(status *pipelinev1beta1.CustomRunStatus) {
+		status.SetCondition(&cond)
+	})
+}
+
+// SetResults sets results on the status of the CustomRun, replacing the results with the same name.
+func (t *CustomRun) SetResults(ctx context.Context, name string, results ...pipelinev1beta1.CustomRunResult) (pipelinev1beta1.CustomRun, error) {
+	return t.ModifyStatus(ctx, name, func(status *pipelinev1beta1.CustomRunStatus) {
+		for _, r := range results {
+			replaced := false
+			for i := range status.Results {
+				if status.Results[i].Name == r.Name {
+					status.Results[i].Value, replaced = r.Value, true
+				}
+			}
+			if !replaced {
+				status.Results = append(status.Results, r)
+			}
+		}
+	})
+}
+
+// MarkRunning sets the Succeeded condition of the CustomRun to Unknown and its start time when unset.
+func (t *CustomRun) MarkRunning(ctx context.Context, name, reason, message string) (pipelinev1beta1.CustomRun, error) {
+	return t.mark(ctx, name, corev1.ConditionUnknown, reason, message)
+}
+
+// MarkSucceeded sets the Succeeded condition of the CustomRun to True and its completion time.
+func (t *CustomRun) MarkSucceeded(ctx context.Context, name, reason, message string) (pipelinev1beta1.CustomRun, error) {
+	return t.mark(ctx, name, corev1.ConditionTrue, reason, message)
+}
+
+// MarkFailed sets the Succeeded condition of the CustomRun to False and its completion time.
+func (t *CustomRun) MarkFailed(ctx context.Context, name, reason, message string) (pipelinev1beta1.CustomRun, error) {
+	return t.mark(ctx, name, corev1.ConditionFalse, reason, message)
+}
+
+func (t *CustomRun) mark(ctx context.Context, name string, status corev1.ConditionStatus, reason, message string) (pipelinev1beta1.CustomRun, error) {
+	return t.ModifyStatus(ctx, name, func(s *pipelinev1beta1.CustomRunStatus) {
+		now := metav1.Now()
+		if s.StartTime == nil {
+			s.StartTime = &now
+		}
+		if status != corev1.ConditionUnknown {
+			s.CompletionTime = &now
+		}
+		s.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+	})
+}
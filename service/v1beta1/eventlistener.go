@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
 	"github.com/hongyuxuan/tekton-sdk-go/service"
-	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
 	"github.com/imroc/req/v3"
 	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 type EventListener struct {
@@ -72,30 +73,59 @@ func (t *EventListener) Get(ctx context.Context, name string) (resp tektonv1beta
 	return
 }
 
+// GetYaml returns the YAML manifest of the EventListener with its metadata but without its status, see manifest.ProfileDefault.
 func (t *EventListener) GetYaml(ctx context.Context, name string) (string, error) {
-	var task types.TektonResource
-	if err := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/eventlisteners/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		SetSuccessResult(&task).
-		Do(ctx).Err; err != nil {
-		return "", err
-	}
-	delete(task.Metadata.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
-	task.Status = nil
-	manifest, _ := yaml.Marshal(task)
-	return string(manifest), nil
+	return t.GetManifest(ctx, name, manifest.Options{})
 }
 
-func (t *EventListener) Delete(ctx context.Context, name string) (err error) {
-	return t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/eventlisteners/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		Do(ctx).Err
+// GetManifest returns the manifest of the EventListener in the format and with the fields stripped by opts.
+func (t *EventListener) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/eventlisteners/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the EventListener; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *EventListener) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/eventlisteners/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1beta1/namespaces/default/eventlisteners?labelSelector=app%3Dtesteventlistener
+func (t *EventListener) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/eventlisteners", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
 }
 
 func (t *EventListener) Create(ctx context.Context, yamlStr string) (err error) {
 	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "EventListener")
 }
 
+// CreateObject creates eventListener in the namespace of the service and returns the object stored by the server.
+func (t *EventListener) CreateObject(ctx context.Context, eventListener *tektonv1beta1.EventListener) (resp tektonv1beta1.EventListener, err error) {
+	obj := eventListener.DeepCopy()
+	obj.APIVersion, obj.Kind = "triggers.tekton.dev/v1beta1", "EventListener"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/eventlisteners", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on eventListener locally, without calling the API server.
+func (t *EventListener) Validate(ctx context.Context, eventListener *tektonv1beta1.EventListener) *apis.FieldError {
+	return validation.Validate(ctx, eventListener)
+}
+
 func (t *EventListener) processItems(items []tektonv1beta1.EventListener) []tektonv1beta1.EventListener {
 	for i := range items {
 		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
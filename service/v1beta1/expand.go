@@ -0,0 +1,129 @@
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/hongyuxuan/tekton-sdk-go/service/v1alpha1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"knative.dev/pkg/apis"
+)
+
+// ExpandedTrigger is a Trigger with its bindings, template and interceptors resolved.
+type ExpandedTrigger struct {
+	Trigger tektonv1beta1.Trigger
+	// Bindings are the bindings in spec order, an inline binding has a single param and no Ref
+	Bindings []ExpandedBinding
+	// Params are the params of all bindings in spec order, the params the template is rendered with
+	Params []tektonv1beta1.Param
+	// TemplateRef is the name of the referenced TriggerTemplate, empty for an embedded template
+	TemplateRef string
+	Template    tektonv1beta1.TriggerTemplateSpec
+	// Interceptors are the interceptors in the order they run
+	Interceptors []ExpandedInterceptor
+}
+
+type ExpandedBinding struct {
+	Ref    string
+	Kind   tektonv1beta1.TriggerBindingKind
+	Params []tektonv1beta1.Param
+}
+
+type ExpandedInterceptor struct {
+	Name    string
+	Kind    tektonv1beta1.InterceptorKind
+	Params  []tektonv1beta1.InterceptorParams
+	Webhook *tektonv1beta1.WebhookInterceptor
+	// URL is the address the EventListener calls the interceptor at, nil for a webhook interceptor
+	URL *apis.URL
+}
+
+// Expand fetches the Trigger and resolves its binding refs to their TriggerBindings or
+// ClusterTriggerBindings, its template ref to the TriggerTemplate, and its interceptor refs to the
+// address of their ClusterInterceptor or Interceptor.
+func (t *Trigger) Expand(ctx context.Context, name string) (*ExpandedTrigger, error) {
+	trigger, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	res := &ExpandedTrigger{Trigger: trigger}
+
+	bindings := NewTriggerBinding(t.config, t.namespace, t.svcCtx)
+	for _, b := range trigger.Spec.Bindings {
+		if b.Ref == "" {
+			param := tektonv1beta1.Param{Name: b.Name}
+			if b.Value != nil {
+				param.Value = *b.Value
+			}
+			res.Bindings = append(res.Bindings, ExpandedBinding{Params: []tektonv1beta1.Param{param}})
+			res.Params = append(res.Params, param)
+			continue
+		}
+		binding := ExpandedBinding{Ref: b.Ref, Kind: b.Kind}
+		if binding.Kind == "" {
+			binding.Kind = tektonv1beta1.NamespacedTriggerBindingKind
+		}
+		switch binding.Kind {
+		case tektonv1beta1.ClusterTriggerBindingKind:
+			ctb, err := NewClusterTriggerBinding(t.config, t.svcCtx).Get(ctx, b.Ref)
+			if err != nil {
+				return nil, err
+			}
+			binding.Params = ctb.Spec.Params
+		default:
+			tb, err := bindings.Get(ctx, b.Ref)
+			if err != nil {
+				return nil, err
+			}
+			binding.Params = tb.Spec.Params
+		}
+		res.Bindings = append(res.Bindings, binding)
+		res.Params = append(res.Params, binding.Params...)
+	}
+
+	switch {
+	case trigger.Spec.Template.Spec != nil:
+		res.Template = *trigger.Spec.Template.Spec
+	case trigger.Spec.Template.Ref != nil:
+		res.TemplateRef = *trigger.Spec.Template.Ref
+		tt, err := NewTriggerTemplate(t.config, t.namespace, t.svcCtx).Get(ctx, res.TemplateRef)
+		if err != nil {
+			return nil, err
+		}
+		res.Template = tt.Spec
+	}
+
+	for _, i := range trigger.Spec.Interceptors {
+		interceptor := ExpandedInterceptor{Kind: i.Ref.Kind, Params: i.Params, Webhook: i.Webhook}
+		if i.Name != nil {
+			interceptor.Name = *i.Name
+		}
+		if i.Webhook == nil {
+			if interceptor.Kind == "" {
+				interceptor.Kind = tektonv1beta1.ClusterInterceptorKind
+			}
+			if interceptor.Name == "" {
+				interceptor.Name = i.Ref.Name
+			}
+			if interceptor.URL, err = t.interceptorURL(ctx, i.Ref); err != nil {
+				return nil, err
+			}
+		}
+		res.Interceptors = append(res.Interceptors, interceptor)
+	}
+	return res, nil
+}
+
+func (t *Trigger) interceptorURL(ctx context.Context, ref tektonv1beta1.InterceptorRef) (*apis.URL, error) {
+	if ref.Kind == tektonv1beta1.NamespacedInterceptorKind {
+		interceptor, err := v1alpha1.NewInterceptor(t.config, t.namespace, t.svcCtx).Get(ctx, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		return interceptor.ResolveAddress()
+	}
+	interceptor, err := v1alpha1.NewClusterInterceptor(t.config, t.svcCtx).Get(ctx, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	return interceptor.ResolveAddress()
+}
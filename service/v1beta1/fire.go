@@ -0,0 +1,180 @@
+package v1beta1
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// firePollInterval is the interval Fire polls for the resources created by an event at.
+const firePollInterval = time.Second
+
+// eventKinds are the kinds Fire looks for the resources created by an event in, the runs triggers create.
+var eventKinds = []string{"PipelineRun", "TaskRun", "CustomRun"}
+
+type FireOptions struct {
+	// UseAddress posts to the status.address.url of the listener, which only resolves inside the
+	// cluster, instead of going through the API server service proxy
+	UseAddress bool
+	// GitHubSecret signs the payload like a GitHub webhook, in X-Hub-Signature-256 and X-Hub-Signature
+	GitHubSecret string
+	// GitLabToken sends the secret token like a GitLab webhook, in X-Gitlab-Token
+	GitLabToken string
+	// Wait polls up to this long for the PipelineRuns, TaskRuns and CustomRuns created by the event,
+	// until their number stops changing between two polls; 0 returns right after the post
+	Wait time.Duration
+}
+
+// FireResponse is the response of the EventListener sink.
+type FireResponse struct {
+	StatusCode       int
+	Body             string
+	EventListener    string `json:"eventListener"`
+	Namespace        string `json:"namespace"`
+	EventListenerUID string `json:"eventListenerUID"`
+	EventID          string `json:"eventID"`
+	ErrorMessage     string `json:"errorMessage"`
+	// Resources are the objects labelled with the eventID, found within FireOptions.Wait
+	Resources []corev1.ObjectReference
+}
+
+// Fire posts the webhook payload with headers to the EventListener, as GitHub or GitLab would
+// when FireOptions sets their secret. The sink processes the event asynchronously: set
+// FireOptions.Wait to collect the resources the triggers created for it.
+//
+//	res, err := client.EventListener("default").Fire(ctx, "github", payload, map[string]string{
+//		"X-GitHub-Event": "push",
+//	}, v1beta1.FireOptions{GitHubSecret: "secret", Wait: 10 * time.Second})
+func (t *EventListener) Fire(ctx context.Context, name string, payload []byte, headers map[string]string, opts ...FireOptions) (*FireResponse, error) {
+	var opt FireOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	el, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req := t.httpclient.Post(t.sinkURL(&el, opt.UseAddress)).
+		SetHeader("Content-Type", "application/json").
+		SetBodyBytes(payload)
+	if !opt.UseAddress {
+		req.SetBearerAuthToken(t.token)
+	}
+	for k, v := range headers {
+		req.SetHeader(k, v)
+	}
+	if opt.GitHubSecret != "" {
+		req.SetHeader("X-Hub-Signature-256", "sha256="+sign(sha256.New, opt.GitHubSecret, payload))
+		req.SetHeader("X-Hub-Signature", "sha1="+sign(sha1.New, opt.GitHubSecret, payload))
+	}
+	if opt.GitLabToken != "" {
+		req.SetHeader("X-Gitlab-Token", opt.GitLabToken)
+	}
+	resp := req.Do(ctx)
+	if resp.Err != nil {
+		return nil, resp.Err
+	}
+	res := &FireResponse{StatusCode: resp.StatusCode, Body: resp.String()}
+	if err = json.Unmarshal(resp.Bytes(), res); err != nil {
+		return res, nil
+	}
+	if res.EventID == "" || opt.Wait <= 0 {
+		return res, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opt.Wait)
+	defer cancel()
+	// triggers create their resources independently, wait until no new one shows up
+	var listErr error
+	found := -1
+	wait.PollUntilContextCancel(waitCtx, firePollInterval, false, func(ctx context.Context) (bool, error) {
+		resources, err := t.eventResources(ctx, res.EventID)
+		if listErr = err; err != nil {
+			return false, nil
+		}
+		res.Resources = resources
+		settled := len(resources) > 0 && len(resources) == found
+		found = len(resources)
+		return settled, nil
+	})
+	if err = ctx.Err(); err != nil {
+		return res, err
+	}
+	if len(res.Resources) == 0 && listErr != nil {
+		return res, listErr
+	}
+	return res, nil
+}
+
+// sinkURL is the status.address.url of el, or its service proxy on the API server.
+func (t *EventListener) sinkURL(el *tektonv1beta1.EventListener, useAddress bool) string {
+	address := eventListenerURL(el)
+	if useAddress && address != "" {
+		return address
+	}
+	service := el.Status.Configuration.GeneratedResourceName
+	if service == "" {
+		service = "el-" + el.Name
+	}
+	scheme, port := "http", "http-listener"
+	if u, err := url.Parse(address); err == nil && u.Scheme == "https" {
+		scheme, port = "https", "https-listener"
+	}
+	return fmt.Sprintf("/api/v1/namespaces/%s/services/%s:%s:%s/proxy/", t.namespace, scheme, service, port)
+}
+
+// eventResources lists the objects of eventKinds labelled with the eventID, skipping the kinds the
+// cluster does not serve.
+func (t *EventListener) eventResources(ctx context.Context, eventID string) ([]corev1.ObjectReference, error) {
+	var res []corev1.ObjectReference
+	for _, name := range eventKinds {
+		kind, _, _ := types.LookupKind(name)
+		var list struct {
+			Items []map[string]interface{} `json:"items"`
+		}
+		if err := t.httpclient.Get(kind.Path(t.namespace)).
+			SetBearerAuthToken(t.token).
+			SetQueryParam("labelSelector", triggers.GroupName+triggers.EventIDLabelKey+"="+eventID).
+			SetSuccessResult(&list).
+			Do(ctx).Err; err != nil {
+			if e, ok := err.(*errorx.TektonError); ok && e.Code == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		for _, item := range list.Items {
+			obj := unstructured.Unstructured{Object: item}
+			res = append(res, corev1.ObjectReference{
+				APIVersion: kind.APIVersion(),
+				Kind:       kind.Kind,
+				Namespace:  t.namespace,
+				Name:       obj.GetName(),
+				UID:        obj.GetUID(),
+			})
+		}
+	}
+	return res, nil
+}
+
+func sign(h func() hash.Hash, secret string, payload []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,194 @@
+package v1beta1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// readyPollInterval is the interval WaitReady polls the EventListener at.
+const readyPollInterval = 2 * time.Second
+
+// EventListenerStatus is the status of an EventListener and of the Deployment, Service and pods
+// the Triggers controller created for it.
+type EventListenerStatus struct {
+	Name  string
+	Ready bool
+	// URL is the status.address.url the sink is served at, empty until the Service exists
+	URL        string
+	Conditions duckv1.Conditions
+	// Deployment and Service are nil when they do not exist (yet), e.g. for a custom resource listener
+	Deployment *DeploymentStatus
+	Service    *ServiceStatus
+	Pods       []PodStatus
+}
+
+type DeploymentStatus struct {
+	Name              string
+	Replicas          int32
+	ReadyReplicas     int32
+	AvailableReplicas int32
+	Ready             bool
+}
+
+type ServiceStatus struct {
+	Name      string
+	Type      corev1.ServiceType
+	ClusterIP string
+	Ports     []corev1.ServicePort
+}
+
+type PodStatus struct {
+	Name     string
+	Phase    corev1.PodPhase
+	Ready    bool
+	Restarts int32
+	// Reason is why a container is not running, e.g. CrashLoopBackOff or ImagePullBackOff
+	Reason string
+	Node   string
+}
+
+// Address returns the status.address.url of the EventListener, empty until its Service exists.
+func (t *EventListener) Address(ctx context.Context, name string) (string, error) {
+	el, err := t.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return eventListenerURL(&el), nil
+}
+
+// WaitReady polls the EventListener until its Ready condition is true and returns it, or returns
+// an error when ctx is done first; bound the wait with a context deadline. An EventListener not
+// found is polled for, any other error of Get is returned at once.
+func (t *EventListener) WaitReady(ctx context.Context, name string) (el tektonv1beta1.EventListener, err error) {
+	var getErr error
+	err = wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		el, getErr = t.Get(ctx, name)
+		if e, ok := getErr.(*errorx.TektonError); ok && e.Code == http.StatusNotFound {
+			// the listener may not be created yet, keep polling
+			return false, nil
+		}
+		if getErr != nil {
+			return false, getErr
+		}
+		return el.Status.GetCondition(apis.ConditionReady).IsTrue(), nil
+	})
+	if err != nil {
+		if getErr != nil {
+			return el, errorx.NewDefaultError("eventlistener %s not ready: %s", name, getErr.Error())
+		}
+		return el, errorx.NewDefaultError("eventlistener %s not ready: %s", name, readyMessage(&el))
+	}
+	return el, nil
+}
+
+// Status returns the readiness of the EventListener, its Deployment, Service and pods, read with
+// the Clientset of the service context.
+func (t *EventListener) Status(ctx context.Context, name string) (*EventListenerStatus, error) {
+	el, err := t.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	res := &EventListenerStatus{
+		Name:       el.Name,
+		Ready:      el.Status.GetCondition(apis.ConditionReady).IsTrue(),
+		URL:        eventListenerURL(&el),
+		Conditions: el.Status.Conditions,
+	}
+	generated := el.Status.Configuration.GeneratedResourceName
+	if generated == "" {
+		generated = "el-" + el.Name
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{"eventlistener": el.Name})
+	deployment, err := t.svcCtx.Clientset.AppsV1().Deployments(t.namespace).Get(ctx, generated, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return nil, err
+	default:
+		res.Deployment = deploymentStatus(deployment)
+		if deployment.Spec.Selector != nil {
+			if s, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector); err == nil {
+				selector = s
+			}
+		}
+	}
+
+	svc, err := t.svcCtx.Clientset.CoreV1().Services(t.namespace).Get(ctx, generated, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return nil, err
+	default:
+		res.Service = &ServiceStatus{Name: svc.Name, Type: svc.Spec.Type, ClusterIP: svc.Spec.ClusterIP, Ports: svc.Spec.Ports}
+	}
+
+	pods, err := t.svcCtx.Clientset.CoreV1().Pods(t.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		res.Pods = append(res.Pods, podStatus(&pods.Items[i]))
+	}
+	return res, nil
+}
+
+func eventListenerURL(el *tektonv1beta1.EventListener) string {
+	if el.Status.Address == nil || el.Status.Address.URL == nil {
+		return ""
+	}
+	return el.Status.Address.URL.String()
+}
+
+func readyMessage(el *tektonv1beta1.EventListener) string {
+	cond := el.Status.GetCondition(apis.ConditionReady)
+	if cond == nil {
+		return "no Ready condition"
+	}
+	return cond.Reason + ": " + cond.Message
+}
+
+func deploymentStatus(d *appsv1.Deployment) *DeploymentStatus {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return &DeploymentStatus{
+		Name:              d.Name,
+		Replicas:          replicas,
+		ReadyReplicas:     d.Status.ReadyReplicas,
+		AvailableReplicas: d.Status.AvailableReplicas,
+		Ready:             d.Status.ObservedGeneration >= d.Generation && d.Status.AvailableReplicas >= replicas,
+	}
+}
+
+func podStatus(pod *corev1.Pod) PodStatus {
+	res := PodStatus{Name: pod.Name, Phase: pod.Status.Phase, Node: pod.Spec.NodeName}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			res.Ready = cond.Status == corev1.ConditionTrue
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		res.Restarts += cs.RestartCount
+		switch {
+		case cs.State.Waiting != nil && res.Reason == "":
+			res.Reason = cs.State.Waiting.Reason
+		case cs.State.Terminated != nil && res.Reason == "":
+			res.Reason = cs.State.Terminated.Reason
+		}
+	}
+	return res
+}
@@ -0,0 +1,125 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	v1 "github.com/hongyuxuan/tekton-sdk-go/service/v1"
+	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/imroc/req/v3"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+)
+
+// Resolution is a ResolutionRequest made to fetch a remote Pipeline, Task or StepAction.
+type Resolution struct {
+	Name string
+	// Resolver is the resolver the request is for, e.g. git, bundles, hub or cluster
+	Resolver string
+	// Owner is the PipelineRun or TaskRun the request was made for, as Kind/name
+	Owner  string
+	Params []tektonv1.Param
+	URL    string
+	// Done is true once the resolver answered, Succeeded when it returned the content; Reason and
+	// Message say why it failed or what it waits for
+	Done      bool
+	Succeeded bool
+	Reason    string
+	Message   string
+	// Data is the resolved YAML, decoded from status.data
+	Data      string
+	RefSource *tektonv1.RefSource
+}
+
+// ForPipelineRun lists the ResolutionRequests made for the PipelineRun and for its child TaskRuns
+// and CustomRuns, oldest first. Owners are matched by UID, so the requests of an earlier run with
+// the same name are left out.
+func (t *ResolutionRequest) ForPipelineRun(ctx context.Context, pipelineRun string) ([]Resolution, error) {
+	pr, err := v1.NewPipelineRun(t.config, t.namespace, t.svcCtx).Get(ctx, pipelineRun)
+	if err != nil {
+		return nil, err
+	}
+	owners := map[k8stypes.UID]string{pr.UID: "PipelineRun/" + pr.Name}
+	for _, kind := range []string{"TaskRun", "CustomRun"} {
+		if err = t.childOwners(ctx, kind, pr.ObjectMeta, owners); err != nil {
+			return nil, err
+		}
+	}
+	requests, err := service.ListAll[resolutionv1beta1.ResolutionRequest](ctx, func() *req.Request {
+		return t.httpclient.Get(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests", t.namespace)).SetBearerAuthToken(t.token)
+	}, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreationTimestamp.Before(&requests[j].CreationTimestamp)
+	})
+	var res []Resolution
+	for i := range requests {
+		for _, ref := range requests[i].OwnerReferences {
+			if owner, ok := owners[ref.UID]; ok {
+				res = append(res, NewResolution(&requests[i], owner))
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// childOwners adds to owners the runs of kind the PipelineRun pr owns, found by their
+// tekton.dev/pipelineRun label; a kind the cluster does not serve has none.
+func (t *ResolutionRequest) childOwners(ctx context.Context, kind string, pr metav1.ObjectMeta, owners map[k8stypes.UID]string) error {
+	resourceKind, _, _ := types.LookupKind(kind)
+	children, err := service.ListAll[metav1.PartialObjectMetadata](ctx, func() *req.Request {
+		return t.httpclient.Get(resourceKind.Path(t.namespace)).SetBearerAuthToken(t.token)
+	}, metav1.ListOptions{LabelSelector: pipeline.PipelineRunLabelKey + "=" + pr.Name})
+	if e, ok := err.(*errorx.TektonError); ok && e.Code == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		for _, ref := range child.OwnerReferences {
+			if ref.UID == pr.UID {
+				owners[child.UID] = kind + "/" + child.Name
+			}
+		}
+	}
+	return nil
+}
+
+// NewResolution summarizes rr made for owner, decoding the resolved content.
+func NewResolution(rr *resolutionv1beta1.ResolutionRequest, owner string) Resolution {
+	res := Resolution{
+		Name:      rr.Name,
+		Resolver:  rr.Labels[common.LabelKeyResolverType],
+		Owner:     owner,
+		Params:    rr.Spec.Params,
+		URL:       rr.Spec.URL,
+		RefSource: rr.Status.RefSource,
+	}
+	if res.RefSource == nil {
+		res.RefSource = rr.Status.Source
+	}
+	if cond := rr.Status.GetCondition(apis.ConditionSucceeded); cond != nil {
+		res.Done, res.Succeeded = !cond.IsUnknown(), cond.IsTrue()
+		res.Reason, res.Message = cond.Reason, cond.Message
+	}
+	if data, err := base64.StdEncoding.DecodeString(rr.Status.Data); err == nil {
+		res.Data = string(data)
+	} else {
+		res.Data = rr.Status.Data
+	}
+	return res
+}
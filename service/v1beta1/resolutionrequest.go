@@ -0,0 +1,141 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/imroc/req/v3"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type ResolutionRequest struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	namespace  string
+	token      string
+}
+
+func NewResolutionRequest(c *config.Config, namespace string, svcCtx *service.ServiceContext) *ResolutionRequest {
+	token, err := svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		panic(err)
+	}
+	return &ResolutionRequest{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		namespace:  namespace,
+		token:      token,
+	}
+}
+
+type ListResolutionRequestResponse struct {
+	ApiVersion string                                `json:"apiVersion"`
+	Items      []resolutionv1beta1.ResolutionRequest `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/resolution.tekton.dev/v1beta1/namespaces/default/resolutionrequests?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *ResolutionRequest) List(ctx context.Context, opts metav1.ListOptions) (resp []resolutionv1beta1.ResolutionRequest, err error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests", t.namespace)).SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListResolutionRequestResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/resolution.tekton.dev/v1beta1/namespaces/default/resolutionrequests?watch=true&labelSelector=app%3Dtestresolutionrequest
+func (t *ResolutionRequest) Watch(ctx context.Context, opts metav1.ListOptions) (<-chan service.WatchEvent[resolutionv1beta1.ResolutionRequest], error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests", t.namespace)).SetBearerAuthToken(t.token)
+	return service.Watch[resolutionv1beta1.ResolutionRequest](ctx, req, opts)
+}
+
+// https://apiserver.cluster.local:6443/apis/resolution.tekton.dev/v1beta1/namespaces/default/resolutionrequests/:name
+func (t *ResolutionRequest) Get(ctx context.Context, name string) (resp resolutionv1beta1.ResolutionRequest, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests/%s", t.namespace, name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the ResolutionRequest with its metadata but without its status, see manifest.ProfileDefault.
+func (t *ResolutionRequest) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the ResolutionRequest in the format and with the fields stripped by opts.
+func (t *ResolutionRequest) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the ResolutionRequest; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *ResolutionRequest) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/resolution.tekton.dev/v1beta1/namespaces/default/resolutionrequests?labelSelector=app%3Dtestresolutionrequest
+func (t *ResolutionRequest) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *ResolutionRequest) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "ResolutionRequest")
+}
+
+// CreateObject creates resolutionRequest in the namespace of the service and returns the object stored by the server,
+// with the name generated from metadata.generateName when no name is set.
+func (t *ResolutionRequest) CreateObject(ctx context.Context, resolutionRequest *resolutionv1beta1.ResolutionRequest) (resp resolutionv1beta1.ResolutionRequest, err error) {
+	obj := resolutionRequest.DeepCopy()
+	obj.APIVersion, obj.Kind = "resolution.tekton.dev/v1beta1", "ResolutionRequest"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/resolution.tekton.dev/v1beta1/namespaces/%s/resolutionrequests", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on resolutionRequest locally, without calling the API server.
+func (t *ResolutionRequest) Validate(ctx context.Context, resolutionRequest *resolutionv1beta1.ResolutionRequest) *apis.FieldError {
+	return validation.Validate(ctx, resolutionRequest)
+}
+
+func (t *ResolutionRequest) processItems(items []resolutionv1beta1.ResolutionRequest) []resolutionv1beta1.ResolutionRequest {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
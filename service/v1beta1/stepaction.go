@@ -0,0 +1,173 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/imroc/req/v3"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type StepAction struct {
+	svcCtx     *service.ServiceContext
+	httpclient *req.Client
+	config     *config.Config
+	namespace  string
+	token      string
+}
+
+func NewStepAction(c *config.Config, namespace string, svcCtx *service.ServiceContext) *StepAction {
+	token, err := svcCtx.GetBearerToken(namespace)
+	if err != nil {
+		panic(err)
+	}
+	return &StepAction{
+		svcCtx:     svcCtx,
+		httpclient: c.Httpclient,
+		config:     c,
+		namespace:  namespace,
+		token:      token,
+	}
+}
+
+type ListStepActionResponse struct {
+	ApiVersion string                       `json:"apiVersion"`
+	Items      []pipelinev1beta1.StepAction `json:"items"`
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/stepactions?labelSelector=app.kubernetes.io%2Fversion%3D0.3&limit=500
+func (t *StepAction) List(ctx context.Context, opts metav1.ListOptions) (resp []pipelinev1beta1.StepAction, err error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions", t.namespace)).SetBearerAuthToken(t.token)
+	if opts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.Limit > 0 {
+		req.SetQueryParam("limit", fmt.Sprintf("%d", opts.Limit))
+	} else {
+		req.SetQueryParam("limit", "500") // default 500
+	}
+	var res ListStepActionResponse
+	if err = req.SetSuccessResult(&res).Do(ctx).Err; err != nil {
+		return
+	}
+
+	return t.processItems(res.Items), nil
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/stepactions?watch=true&labelSelector=app%3Dteststepaction
+func (t *StepAction) Watch(ctx context.Context, opts metav1.ListOptions) (<-chan service.WatchEvent[pipelinev1beta1.StepAction], error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions", t.namespace)).SetBearerAuthToken(t.token)
+	return service.Watch[pipelinev1beta1.StepAction](ctx, req, opts)
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/stepactions/:name
+func (t *StepAction) Get(ctx context.Context, name string) (resp pipelinev1beta1.StepAction, err error) {
+	if err = t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions/%s", t.namespace, name)).
+		SetBearerAuthToken(t.token).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// GetYaml returns the YAML manifest of the StepAction with its metadata but without its status, see manifest.ProfileDefault.
+func (t *StepAction) GetYaml(ctx context.Context, name string) (string, error) {
+	return t.GetManifest(ctx, name, manifest.Options{})
+}
+
+// GetManifest returns the manifest of the StepAction in the format and with the fields stripped by opts.
+func (t *StepAction) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the StepAction; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *StepAction) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/tekton.dev/v1beta1/namespaces/default/stepactions?labelSelector=app%3Dteststepaction
+func (t *StepAction) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+func (t *StepAction) Create(ctx context.Context, yamlStr string) (err error) {
+	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "StepAction")
+}
+
+// CreateObject creates stepAction in the namespace of the service and returns the object stored by the server.
+func (t *StepAction) CreateObject(ctx context.Context, stepAction *pipelinev1beta1.StepAction) (resp pipelinev1beta1.StepAction, err error) {
+	obj := stepAction.DeepCopy()
+	obj.APIVersion, obj.Kind = "tekton.dev/v1beta1", "StepAction"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%s/stepactions", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on stepAction locally, without calling the API server.
+func (t *StepAction) Validate(ctx context.Context, stepAction *pipelinev1beta1.StepAction) *apis.FieldError {
+	return validation.Validate(ctx, stepAction)
+}
+
+func (t *StepAction) processItems(items []pipelinev1beta1.StepAction) []pipelinev1beta1.StepAction {
+	for i := range items {
+		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		items[i].ObjectMeta.ManagedFields = nil
+	}
+	return items
+}
+
+// StepActionReference is a Task with the names of its steps that reference a StepAction.
+type StepActionReference struct {
+	Task  string
+	Steps []string
+}
+
+// ReferencedBy lists the Tasks in the namespace of the service with a step whose ref names the
+// StepAction; steps fetching a StepAction through a resolver are not matched.
+func (t *StepAction) ReferencedBy(ctx context.Context, name string) ([]StepActionReference, error) {
+	tasks, err := service.ListAll[tektonv1.Task](ctx, func() *req.Request {
+		return t.httpclient.Get(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/%s/tasks", t.namespace)).SetBearerAuthToken(t.token)
+	}, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var res []StepActionReference
+	for _, task := range tasks {
+		var steps []string
+		for _, step := range task.Spec.Steps {
+			if step.Ref != nil && step.Ref.Resolver == "" && step.Ref.Name == name {
+				steps = append(steps, step.Name)
+			}
+		}
+		if len(steps) > 0 {
+			res = append(res, StepActionReference{Task: task.Name, Steps: steps})
+		}
+	}
+	return res, nil
+}
@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
 	"github.com/hongyuxuan/tekton-sdk-go/service"
-	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
 	"github.com/imroc/req/v3"
 	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 type TriggerBinding struct {
@@ -72,30 +73,59 @@ func (t *TriggerBinding) Get(ctx context.Context, name string) (resp tektonv1bet
 	return
 }
 
+// GetYaml returns the YAML manifest of the TriggerBinding with its metadata but without its status, see manifest.ProfileDefault.
 func (t *TriggerBinding) GetYaml(ctx context.Context, name string) (string, error) {
-	var task types.TektonResource
-	if err := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggerbindings/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		SetSuccessResult(&task).
-		Do(ctx).Err; err != nil {
-		return "", err
-	}
-	delete(task.Metadata.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
-	task.Status = nil
-	manifest, _ := yaml.Marshal(task)
-	return string(manifest), nil
+	return t.GetManifest(ctx, name, manifest.Options{})
 }
 
-func (t *TriggerBinding) Delete(ctx context.Context, name string) (err error) {
-	return t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggerbindings/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		Do(ctx).Err
+// GetManifest returns the manifest of the TriggerBinding in the format and with the fields stripped by opts.
+func (t *TriggerBinding) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggerbindings/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the TriggerBinding; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *TriggerBinding) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggerbindings/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1beta1/namespaces/default/triggerbindings?labelSelector=app%3Dtesttriggerbinding
+func (t *TriggerBinding) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggerbindings", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
 }
 
 func (t *TriggerBinding) Create(ctx context.Context, yamlStr string) (err error) {
 	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "TriggerBinding")
 }
 
+// CreateObject creates triggerBinding in the namespace of the service and returns the object stored by the server.
+func (t *TriggerBinding) CreateObject(ctx context.Context, triggerBinding *tektonv1beta1.TriggerBinding) (resp tektonv1beta1.TriggerBinding, err error) {
+	obj := triggerBinding.DeepCopy()
+	obj.APIVersion, obj.Kind = "triggers.tekton.dev/v1beta1", "TriggerBinding"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggerbindings", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on triggerBinding locally, without calling the API server.
+func (t *TriggerBinding) Validate(ctx context.Context, triggerBinding *tektonv1beta1.TriggerBinding) *apis.FieldError {
+	return validation.Validate(ctx, triggerBinding)
+}
+
 func (t *TriggerBinding) processItems(items []tektonv1beta1.TriggerBinding) []tektonv1beta1.TriggerBinding {
 	for i := range items {
 		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
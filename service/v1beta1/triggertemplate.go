@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/hongyuxuan/tekton-sdk-go/config"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
 	"github.com/hongyuxuan/tekton-sdk-go/service"
-	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
 	"github.com/imroc/req/v3"
 	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 type TriggerTemplate struct {
@@ -72,30 +73,59 @@ func (t *TriggerTemplate) Get(ctx context.Context, name string) (resp tektonv1be
 	return
 }
 
+// GetYaml returns the YAML manifest of the TriggerTemplate with its metadata but without its status, see manifest.ProfileDefault.
 func (t *TriggerTemplate) GetYaml(ctx context.Context, name string) (string, error) {
-	var task types.TektonResource
-	if err := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggertemplates/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		SetSuccessResult(&task).
-		Do(ctx).Err; err != nil {
-		return "", err
-	}
-	delete(task.Metadata.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
-	task.Status = nil
-	manifest, _ := yaml.Marshal(task)
-	return string(manifest), nil
+	return t.GetManifest(ctx, name, manifest.Options{})
 }
 
-func (t *TriggerTemplate) Delete(ctx context.Context, name string) (err error) {
-	return t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggertemplates/%s", t.namespace, name)).
-		SetBearerAuthToken(t.token).
-		Do(ctx).Err
+// GetManifest returns the manifest of the TriggerTemplate in the format and with the fields stripped by opts.
+func (t *TriggerTemplate) GetManifest(ctx context.Context, name string, opts manifest.Options) (string, error) {
+	req := t.httpclient.Get(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggertemplates/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.GetManifest(ctx, req, opts)
+}
+
+// Delete deletes the TriggerTemplate; opts sets the propagation policy, grace period, preconditions or dry-run.
+func (t *TriggerTemplate) Delete(ctx context.Context, name string, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggertemplates/%s", t.namespace, name)).SetBearerAuthToken(t.token)
+	return service.DeleteWithOptions(ctx, req, opts...)
+}
+
+// https://apiserver.cluster.local:6443/apis/triggers.tekton.dev/v1beta1/namespaces/default/triggertemplates?labelSelector=app%3Dtesttriggertemplate
+func (t *TriggerTemplate) DeleteCollection(ctx context.Context, listOpts metav1.ListOptions, opts ...metav1.DeleteOptions) (err error) {
+	req := t.httpclient.Delete(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggertemplates", t.namespace)).SetBearerAuthToken(t.token)
+	if listOpts.LabelSelector != "" {
+		req.SetQueryParam("labelSelector", listOpts.LabelSelector)
+	}
+	if listOpts.FieldSelector != "" {
+		req.SetQueryParam("fieldSelector", listOpts.FieldSelector)
+	}
+	return service.DeleteWithOptions(ctx, req, opts...)
 }
 
 func (t *TriggerTemplate) Create(ctx context.Context, yamlStr string) (err error) {
 	return t.svcCtx.ApplyYaml(ctx, t.namespace, yamlStr, "TriggerTemplate")
 }
 
+// CreateObject creates triggerTemplate in the namespace of the service and returns the object stored by the server.
+func (t *TriggerTemplate) CreateObject(ctx context.Context, triggerTemplate *tektonv1beta1.TriggerTemplate) (resp tektonv1beta1.TriggerTemplate, err error) {
+	obj := triggerTemplate.DeepCopy()
+	obj.APIVersion, obj.Kind = "triggers.tekton.dev/v1beta1", "TriggerTemplate"
+	obj.Namespace = t.namespace
+	if err = t.httpclient.Post(fmt.Sprintf("/apis/triggers.tekton.dev/v1beta1/namespaces/%s/triggertemplates", t.namespace)).
+		SetBearerAuthToken(t.token).
+		SetBody(obj).
+		SetSuccessResult(&resp).
+		Do(ctx).Err; err != nil {
+		return
+	}
+	return
+}
+
+// Validate runs the webhook defaulting and validation on triggerTemplate locally, without calling the API server.
+func (t *TriggerTemplate) Validate(ctx context.Context, triggerTemplate *tektonv1beta1.TriggerTemplate) *apis.FieldError {
+	return validation.Validate(ctx, triggerTemplate)
+}
+
 func (t *TriggerTemplate) processItems(items []tektonv1beta1.TriggerTemplate) []tektonv1beta1.TriggerTemplate {
 	for i := range items {
 		delete(items[i].ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/imroc/req/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchClients holds a copy of each client without its request timeout, which would also cut off
+// reading the watch stream; a watch ends with ctx or the timeoutSeconds of its options instead
+var watchClients sync.Map
+
+type WatchEvent[T any] struct {
+	Type   watch.EventType
+	Object T
+	// Err is set on an ERROR event, e.g. when the resourceVersion to watch from is too old
+	Err error
+}
+
+// Watch starts the watch request r on a collection and streams its events decoded as T. The
+// channel is closed when ctx is done or the server ends the watch, after an ERROR event when the
+// stream broke off instead; watch again from the resourceVersion of the last event to resume.
+func Watch[T any](ctx context.Context, r *req.Request, opts metav1.ListOptions) (<-chan WatchEvent[T], error) {
+	r.SetQueryParam("watch", "true")
+	if opts.LabelSelector != "" {
+		r.SetQueryParam("labelSelector", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		r.SetQueryParam("fieldSelector", opts.FieldSelector)
+	}
+	if opts.ResourceVersion != "" {
+		r.SetQueryParam("resourceVersion", opts.ResourceVersion)
+	}
+	if opts.TimeoutSeconds != nil {
+		r.SetQueryParam("timeoutSeconds", fmt.Sprintf("%d", *opts.TimeoutSeconds))
+	}
+	if opts.AllowWatchBookmarks {
+		r.SetQueryParam("allowWatchBookmarks", "true")
+	}
+	if c := r.GetClient(); c != nil {
+		wc, ok := watchClients.Load(c)
+		if !ok {
+			wc, _ = watchClients.LoadOrStore(c, c.Clone().SetTimeout(0))
+		}
+		r.SetClient(wc.(*req.Client))
+	}
+	resp := r.DisableAutoReadResponse().Do(ctx)
+	if resp.Err != nil {
+		return nil, resp.Err
+	}
+	ch := make(chan WatchEvent[T])
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+		d := json.NewDecoder(resp.Body)
+		for {
+			var e struct {
+				Type   watch.EventType `json:"type"`
+				Object json.RawMessage `json:"object"`
+			}
+			if err := d.Decode(&e); err != nil {
+				if err == io.EOF || ctx.Err() != nil {
+					return
+				}
+				// the stream broke off, e.g. the connection was reset, tell it from the end of the watch
+				select {
+				case ch <- WatchEvent[T]{Type: watch.Error, Err: errorx.NewDefaultError("watch stream broken: %s", err.Error())}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			event := WatchEvent[T]{Type: e.Type}
+			if e.Type == watch.Error {
+				var status metav1.Status
+				json.Unmarshal(e.Object, &status)
+				event.Err = errorx.NewError(int64(status.Code), status.Message, status)
+			} else if err := json.Unmarshal(e.Object, &event.Object); err != nil {
+				event.Type, event.Err = watch.Error, errorx.NewDefaultError("cannot decode watch event: %s", err.Error())
+			}
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,151 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/bitbucket"
+	"github.com/tektoncd/triggers/pkg/interceptors/cel"
+	"github.com/tektoncd/triggers/pkg/interceptors/github"
+	"github.com/tektoncd/triggers/pkg/interceptors/gitlab"
+	"google.golang.org/grpc/codes"
+)
+
+type InterceptOptions struct {
+	// Namespace is the namespace of the trigger, default "default"
+	Namespace string
+	// Trigger is the name of the trigger, passed to the interceptors in the trigger ID
+	Trigger string
+	// Secrets are the secrets the interceptors read, e.g. with compareSecret, by name then key
+	Secrets map[string]map[string]string
+}
+
+// InterceptResult is the outcome of an interceptor chain for an event.
+type InterceptResult struct {
+	// Match is true when every interceptor let the event through
+	Match bool
+	// StoppedBy is the name of the interceptor that stopped the event, and Message its reason
+	StoppedBy string
+	Message   string
+	// Extensions are the extensions of the event after the interceptors that ran, with the overlays
+	Extensions map[string]interface{}
+	// Skipped are the interceptors that cannot run locally, e.g. webhooks or custom interceptors
+	Skipped []string
+}
+
+// Intercept runs the interceptors against event like the EventListener sink does, with the CEL
+// interceptor and its Triggers extension functions, and the GitHub, GitLab and Bitbucket
+// signature and event type checks evaluated locally. A filter that does not return true, or an
+// event the webhook interceptors reject, stops the chain with Match false; a CEL expression that
+// does not compile or evaluate is an error.
+func Intercept(ctx context.Context, event Event, ics []*tektonv1beta1.TriggerInterceptor, opts ...InterceptOptions) (*InterceptResult, error) {
+	var opt InterceptOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Namespace == "" {
+		opt.Namespace = "default"
+	}
+	res := &InterceptResult{Match: true, Extensions: make(map[string]interface{})}
+	for k, v := range event.Extensions {
+		res.Extensions[k] = v
+	}
+	sg := secretGetter(opt.Secrets)
+	for i, ic := range ics {
+		name := interceptorName(i, ic)
+		var impl tektonv1beta1.InterceptorInterface
+		// the webhook interceptors reject a bad signature or a missing header with InvalidArgument
+		rejects := true
+		switch {
+		case ic.Webhook == nil && ic.Ref.Name == "cel":
+			impl, rejects = cel.NewInterceptor(sg), false
+		case ic.Webhook == nil && ic.Ref.Name == "github":
+			impl = github.NewInterceptor(sg)
+		case ic.Webhook == nil && ic.Ref.Name == "gitlab":
+			impl = gitlab.NewInterceptor(sg)
+		case ic.Webhook == nil && ic.Ref.Name == "bitbucket":
+			impl = bitbucket.NewInterceptor(sg)
+		default:
+			res.Skipped = append(res.Skipped, name)
+			continue
+		}
+		body := "{}"
+		if len(event.Body) > 0 {
+			body = string(event.Body)
+		}
+		resp := impl.Process(ctx, &tektonv1beta1.InterceptorRequest{
+			Body:              body,
+			Header:            event.Header,
+			Extensions:        res.Extensions,
+			InterceptorParams: interceptors.GetInterceptorParams(ic),
+			Context: &tektonv1beta1.TriggerContext{
+				EventURL:  event.URL,
+				EventID:   event.EventID,
+				TriggerID: fmt.Sprintf("namespaces/%s/triggers/%s", opt.Namespace, opt.Trigger),
+			},
+		})
+		if !resp.Continue {
+			if resp.Status.Code != codes.FailedPrecondition && !rejects {
+				return nil, errorx.NewDefaultError("interceptor %s: %s", name, resp.Status.Message)
+			}
+			res.Match, res.StoppedBy, res.Message = false, name, resp.Status.Message
+			return res, nil
+		}
+		for k, v := range resp.Extensions {
+			res.Extensions[k] = v
+		}
+	}
+	return res, nil
+}
+
+// InterceptEventListener runs the interceptors of the trigger named trigger in el. A trigger
+// referencing a Trigger object has to be resolved first, see v1beta1.Trigger.Expand, and its
+// interceptors run with Intercept.
+func InterceptEventListener(ctx context.Context, event Event, el *tektonv1beta1.EventListener, trigger string, opts ...InterceptOptions) (*InterceptResult, error) {
+	var opt InterceptOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Namespace == "" {
+		opt.Namespace = el.Namespace
+	}
+	for _, t := range el.Spec.Triggers {
+		if t.Name != trigger {
+			continue
+		}
+		if t.TriggerRef != "" {
+			return nil, errorx.NewDefaultError("trigger %s references Trigger %s, resolve it with the Trigger service", trigger, t.TriggerRef)
+		}
+		opt.Trigger = trigger
+		return Intercept(ctx, event, t.Interceptors, opt)
+	}
+	return nil, errorx.NewDefaultError("eventlistener %s has no trigger %s", el.Name, trigger)
+}
+
+func interceptorName(i int, ic *tektonv1beta1.TriggerInterceptor) string {
+	switch {
+	case ic.Name != nil && *ic.Name != "":
+		return *ic.Name
+	case ic.Webhook != nil:
+		return fmt.Sprintf("webhook[%d]", i)
+	}
+	return ic.Ref.Name
+}
+
+// secretGetter serves InterceptOptions.Secrets to the interceptors.
+type secretGetter map[string]map[string]string
+
+func (s secretGetter) Get(_ context.Context, triggerNS string, sr *tektonv1beta1.SecretRef) ([]byte, error) {
+	secret, ok := s[sr.SecretName]
+	if !ok {
+		return nil, errorx.NewDefaultError("secret %s/%s not found", triggerNS, sr.SecretName)
+	}
+	value, ok := secret[sr.SecretKey]
+	if !ok {
+		return nil, errorx.NewDefaultError("cannot find %s key in secret %s/%s", sr.SecretKey, triggerNS, sr.SecretName)
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,109 @@
+// Package simulate renders what a Trigger would create for a webhook event, without a cluster: the
+// binding params are evaluated against the event and substituted into the TriggerTemplate with
+// the same code the EventListener sink runs.
+package simulate
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	v1beta1 "github.com/hongyuxuan/tekton-sdk-go/service/v1beta1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/template"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Event is a webhook event as the EventListener sink sees it.
+type Event struct {
+	Body []byte
+	// Header is read with canonical keys like an HTTP server does, build it with Header.Set
+	Header http.Header
+	// Extensions are the fields added by interceptors, read by $(extensions.x)
+	Extensions map[string]interface{}
+	// EventID is the value of $(context.eventID), a random UUID when empty
+	EventID string
+	// URL is the URL the event was posted to, requestURL in CEL expressions
+	URL string
+}
+
+type Result struct {
+	EventID string
+	// Params are the template params with the binding expressions evaluated
+	Params []tektonv1beta1.Param
+	// Resources are the resource templates with $(tt.params.x) and $(uid) substituted
+	Resources []*unstructured.Unstructured
+}
+
+// Render evaluates the params of bindings, TriggerBindings or ClusterTriggerBindings in order,
+// against event and renders tt with them.
+func Render(event Event, tt *tektonv1beta1.TriggerTemplate, bindings ...tektonv1beta1.TriggerBindingInterface) (*Result, error) {
+	var params []tektonv1beta1.Param
+	for _, b := range bindings {
+		params = append(params, b.TriggerBindingSpec().Params...)
+	}
+	return RenderParams(event, tt, params)
+}
+
+// RenderTrigger renders a Trigger resolved by the Trigger service, see v1beta1.Trigger.Expand.
+func RenderTrigger(event Event, trigger *v1beta1.ExpandedTrigger) (*Result, error) {
+	return RenderParams(event, &tektonv1beta1.TriggerTemplate{Spec: trigger.Template}, trigger.Params)
+}
+
+// RenderParams evaluates the binding params against event and renders tt with them.
+func RenderParams(event Event, tt *tektonv1beta1.TriggerTemplate, params []tektonv1beta1.Param) (*Result, error) {
+	if event.EventID == "" {
+		event.EventID = uuid.NewString()
+	}
+	rt := template.ResolvedTrigger{TriggerTemplate: tt, BindingParams: params}
+	resolved, err := template.ResolveParams(rt, event.Body, event.Header, event.Extensions, template.NewTriggerContext(event.EventID))
+	if err != nil {
+		return nil, errorx.NewDefaultError("cannot resolve params: %s", err.Error())
+	}
+	res := &Result{EventID: event.EventID, Params: resolved}
+	for i, raw := range template.ResolveResources(tt, resolved) {
+		obj := make(map[string]interface{})
+		if err = json.Unmarshal(raw, &obj); err != nil {
+			return nil, errorx.NewDefaultError("resource template %d is not valid JSON after substitution: %s", i, err.Error())
+		}
+		res.Resources = append(res.Resources, &unstructured.Unstructured{Object: obj})
+	}
+	return res, nil
+}
+
+// RenderYaml renders the TriggerTemplate of a multi-document YAML with its TriggerBindings and
+// ClusterTriggerBindings, in the order of the documents.
+func RenderYaml(event Event, yamlStr string) (*Result, error) {
+	objs, err := manifest.Unmarshal([]byte(yamlStr))
+	if err != nil {
+		return nil, err
+	}
+	var tt *tektonv1beta1.TriggerTemplate
+	var bindings []tektonv1beta1.TriggerBindingInterface
+	for _, obj := range objs {
+		var typed interface{}
+		switch obj.GetKind() {
+		case "TriggerTemplate":
+			tt = &tektonv1beta1.TriggerTemplate{}
+			typed = tt
+		case "TriggerBinding":
+			b := &tektonv1beta1.TriggerBinding{}
+			bindings, typed = append(bindings, b), b
+		case "ClusterTriggerBinding":
+			b := &tektonv1beta1.ClusterTriggerBinding{}
+			bindings, typed = append(bindings, b), b
+		default:
+			return nil, errorx.NewDefaultError("unexpected kind %s of %s", obj.GetKind(), obj.GetName())
+		}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+			return nil, errorx.NewDefaultError("cannot decode %s %s: %s", obj.GetKind(), obj.GetName(), err.Error())
+		}
+	}
+	if tt == nil {
+		return nil, errorx.NewDefaultError("no TriggerTemplate found")
+	}
+	return Render(event, tt, bindings...)
+}
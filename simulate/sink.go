@@ -0,0 +1,325 @@
+package simulate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/tektoncd/triggers/pkg/apis/triggers"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Sink is an EventListener sink that runs in process: it accepts webhooks over HTTP for the
+// EventListeners loaded with Load, runs the interceptors of their triggers with Intercept and
+// records the resources the triggers would create instead of creating them. An event is posted
+// to /<eventlistener>, or to / when a single EventListener is loaded.
+//
+//	sink := simulate.NewSink()
+//	err := sink.Load(yamlStr)
+//	server := httptest.NewServer(sink)
+//	defer server.Close()
+//
+// Trigger groups and namespace selectors are not supported, every object is looked up by name.
+type Sink struct {
+	// Secrets are the secrets the interceptors read, see InterceptOptions
+	Secrets map[string]map[string]string
+
+	mu              sync.Mutex
+	listeners       map[string]*tektonv1beta1.EventListener
+	triggers        map[string]*tektonv1beta1.Trigger
+	bindings        map[string]*tektonv1beta1.TriggerBinding
+	clusterBindings map[string]*tektonv1beta1.ClusterTriggerBinding
+	templates       map[string]*tektonv1beta1.TriggerTemplate
+	records         []Record
+}
+
+// Record is an event received by the Sink, with what each trigger of the EventListener did.
+type Record struct {
+	EventID       string
+	EventListener string
+	Namespace     string
+	Event         Event
+	Triggers      []TriggerRecord
+}
+
+type TriggerRecord struct {
+	Trigger string
+	// Intercept is the outcome of the interceptors, nil when the trigger failed before they ran
+	Intercept *InterceptResult
+	// Resources are the rendered resources labelled as the sink labels them, nil when the
+	// interceptors stopped the event
+	Resources []*unstructured.Unstructured
+	// Err is why the trigger failed, e.g. a missing binding or an interceptor error
+	Err error
+}
+
+// sinkResponse is the body the EventListener sink responds with.
+type sinkResponse struct {
+	EventListener    string `json:"eventListener"`
+	Namespace        string `json:"namespace,omitempty"`
+	EventListenerUID string `json:"eventListenerUID"`
+	EventID          string `json:"eventID"`
+}
+
+func NewSink() *Sink {
+	return &Sink{
+		listeners:       make(map[string]*tektonv1beta1.EventListener),
+		triggers:        make(map[string]*tektonv1beta1.Trigger),
+		bindings:        make(map[string]*tektonv1beta1.TriggerBinding),
+		clusterBindings: make(map[string]*tektonv1beta1.ClusterTriggerBinding),
+		templates:       make(map[string]*tektonv1beta1.TriggerTemplate),
+	}
+}
+
+// Load adds the EventListeners, Triggers, TriggerBindings, ClusterTriggerBindings and
+// TriggerTemplates of a multi-document YAML, replacing the loaded ones with the same name.
+func (s *Sink) Load(yamlStr string) error {
+	objs, err := manifest.Unmarshal([]byte(yamlStr))
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, obj := range objs {
+		var typed interface{}
+		switch obj.GetKind() {
+		case "EventListener":
+			el := &tektonv1beta1.EventListener{}
+			s.listeners[obj.GetName()], typed = el, el
+		case "Trigger":
+			t := &tektonv1beta1.Trigger{}
+			s.triggers[obj.GetName()], typed = t, t
+		case "TriggerBinding":
+			b := &tektonv1beta1.TriggerBinding{}
+			s.bindings[obj.GetName()], typed = b, b
+		case "ClusterTriggerBinding":
+			b := &tektonv1beta1.ClusterTriggerBinding{}
+			s.clusterBindings[obj.GetName()], typed = b, b
+		case "TriggerTemplate":
+			tt := &tektonv1beta1.TriggerTemplate{}
+			s.templates[obj.GetName()], typed = tt, tt
+		default:
+			return errorx.NewDefaultError("unexpected kind %s of %s", obj.GetKind(), obj.GetName())
+		}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+			return errorx.NewDefaultError("cannot decode %s %s: %s", obj.GetKind(), obj.GetName(), err.Error())
+		}
+	}
+	return nil
+}
+
+// Records returns the events received since the last Reset, oldest first.
+func (s *Sink) Records() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Record(nil), s.records...)
+}
+
+// Resources returns the resources rendered for every event received since the last Reset.
+func (s *Sink) Resources() []*unstructured.Unstructured {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res []*unstructured.Unstructured
+	for _, r := range s.records {
+		for _, t := range r.Triggers {
+			res = append(res, t.Resources...)
+		}
+	}
+	return res
+}
+
+// Reset forgets the recorded events, the loaded definitions are kept.
+func (s *Sink) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = nil
+}
+
+// ServeHTTP processes the event synchronously and responds 202 Accepted like the sink does,
+// whether or not the triggers matched; see Records for the outcome.
+func (s *Sink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	el, err := s.eventListener(strings.Trim(r.URL.Path, "/"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	namespace := el.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	event := Event{Body: body, Header: r.Header, EventID: uuid.NewString(), URL: r.URL.String()}
+	record := Record{EventID: event.EventID, EventListener: el.Name, Namespace: namespace, Event: event}
+	for _, t := range s.eventListenerTriggers(el) {
+		record.Triggers = append(record.Triggers, s.process(r, event, el.Name, namespace, t))
+	}
+	s.mu.Lock()
+	s.records = append(s.records, record)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(sinkResponse{
+		EventListener:    el.Name,
+		Namespace:        namespace,
+		EventListenerUID: string(el.UID),
+		EventID:          event.EventID,
+	})
+}
+
+func (s *Sink) eventListener(name string) (*tektonv1beta1.EventListener, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if name == "" {
+		if len(s.listeners) != 1 {
+			return nil, errorx.NewDefaultError("%d eventlisteners loaded, post the event to /<eventlistener>", len(s.listeners))
+		}
+		for _, el := range s.listeners {
+			return el, nil
+		}
+	}
+	el, ok := s.listeners[name]
+	if !ok {
+		return nil, errorx.NewDefaultError("eventlistener %s not found", name)
+	}
+	return el, nil
+}
+
+// eventListenerTriggers returns the triggers of el with their names, resolving triggerRefs and
+// the labelSelector against the loaded Triggers; an unresolved triggerRef is kept as is.
+func (s *Sink) eventListenerTriggers(el *tektonv1beta1.EventListener) []tektonv1beta1.EventListenerTrigger {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res []tektonv1beta1.EventListenerTrigger
+	for _, t := range el.Spec.Triggers {
+		if t.TriggerRef != "" {
+			if trigger, ok := s.triggers[t.TriggerRef]; ok {
+				if elt, err := tektonv1beta1.ToEventListenerTrigger(trigger.Spec); err == nil {
+					elt.Name = trigger.Name
+					t = elt
+				}
+			}
+		}
+		res = append(res, t)
+	}
+	if el.Spec.LabelSelector == nil {
+		return res
+	}
+	selector, err := metav1.LabelSelectorAsSelector(el.Spec.LabelSelector)
+	if err != nil {
+		return res
+	}
+	names := make([]string, 0, len(s.triggers))
+	for name := range s.triggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		trigger := s.triggers[name]
+		if !selector.Matches(labels.Set(trigger.Labels)) {
+			continue
+		}
+		if elt, err := tektonv1beta1.ToEventListenerTrigger(trigger.Spec); err == nil {
+			elt.Name = trigger.Name
+			res = append(res, elt)
+		}
+	}
+	return res
+}
+
+func (s *Sink) process(r *http.Request, event Event, el, namespace string, t tektonv1beta1.EventListenerTrigger) TriggerRecord {
+	if t.TriggerRef != "" {
+		return TriggerRecord{Trigger: t.TriggerRef, Err: errorx.NewDefaultError("trigger %s not found", t.TriggerRef)}
+	}
+	name := t.Name
+	res := TriggerRecord{Trigger: name}
+	res.Intercept, res.Err = Intercept(r.Context(), event, t.Interceptors, InterceptOptions{
+		Namespace: namespace,
+		Trigger:   name,
+		Secrets:   s.Secrets,
+	})
+	if res.Err != nil || !res.Intercept.Match {
+		return res
+	}
+	params, tt, err := s.resolve(t)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	event.Extensions = res.Intercept.Extensions
+	rendered, err := RenderParams(event, tt, params)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	for _, obj := range rendered.Resources {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		l := obj.GetLabels()
+		if l == nil {
+			l = make(map[string]string)
+		}
+		l[triggers.GroupName+triggers.EventListenerLabelKey] = el
+		l[triggers.GroupName+triggers.TriggerLabelKey] = name
+		l[triggers.GroupName+triggers.EventIDLabelKey] = event.EventID
+		obj.SetLabels(l)
+	}
+	res.Resources = rendered.Resources
+	return res
+}
+
+// resolve returns the binding params in order and the TriggerTemplate of t.
+func (s *Sink) resolve(t tektonv1beta1.EventListenerTrigger) ([]tektonv1beta1.Param, *tektonv1beta1.TriggerTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var params []tektonv1beta1.Param
+	for _, b := range t.Bindings {
+		switch {
+		case b.Ref == "":
+			if b.Value == nil {
+				return nil, nil, errorx.NewDefaultError("binding %s has no value", b.Name)
+			}
+			params = append(params, tektonv1beta1.Param{Name: b.Name, Value: *b.Value})
+		case b.Kind == tektonv1beta1.ClusterTriggerBindingKind:
+			ctb, ok := s.clusterBindings[b.Ref]
+			if !ok {
+				return nil, nil, errorx.NewDefaultError("clustertriggerbinding %s not found", b.Ref)
+			}
+			params = append(params, ctb.Spec.Params...)
+		default:
+			tb, ok := s.bindings[b.Ref]
+			if !ok {
+				return nil, nil, errorx.NewDefaultError("triggerbinding %s not found", b.Ref)
+			}
+			params = append(params, tb.Spec.Params...)
+		}
+	}
+	switch {
+	case t.Template == nil:
+		return nil, nil, errorx.NewDefaultError("trigger %s has no template", t.Name)
+	case t.Template.Spec != nil:
+		return params, &tektonv1beta1.TriggerTemplate{Spec: *t.Template.Spec}, nil
+	case t.Template.Ref != nil:
+		tt, ok := s.templates[*t.Template.Ref]
+		if !ok {
+			return nil, nil, errorx.NewDefaultError("triggertemplate %s not found", *t.Template.Ref)
+		}
+		return params, tt, nil
+	}
+	return nil, nil, errorx.NewDefaultError("trigger %s has no template", t.Name)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/service/approval"
+	"github.com/hongyuxuan/tekton-sdk-go/types"
+	"github.com/stretchr/testify/suite"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SuiteTestApproval struct {
+	suite.Suite
+	server    *fakeAPIServer
+	namespace string
+}
+
+func (s *SuiteTestApproval) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+	for _, name := range []string{"deploy-approve", "hotfix-approve"} {
+		labels := map[string]string{"tekton.dev/pipelineRun": name + "-run", "tekton.dev/pipelineTask": "approve"}
+		s.server.addJSON("/apis/tekton.dev/v1beta1/namespaces/default/customruns", pipelinev1beta1.CustomRun{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "CustomRun"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.namespace, Labels: labels},
+			Spec: pipelinev1beta1.CustomRunSpec{
+				CustomRef: &pipelinev1beta1.TaskRef{APIVersion: "openshift-pipelines.org/v1alpha1", Kind: "ApprovalTask"},
+			},
+		})
+		_, err := s.server.client().ApprovalTask(s.namespace).CreateObject(context.TODO(), &types.ApprovalTask{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec: types.ApprovalTaskSpec{
+				Approvers: []types.ApproverDetails{
+					{Name: "alice", Input: types.ApprovalInputPending, Type: types.ApproverTypeUser},
+					{Name: "release-team", Input: types.ApprovalInputPending, Type: types.ApproverTypeGroup},
+				},
+				NumberOfApprovalsRequired: 2,
+				Description:               "deploy to production",
+			},
+			Status: types.ApprovalTaskStatus{State: types.ApprovalStatePending},
+		})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *SuiteTestApproval) TearDownSuite() {
+	s.server.Close()
+}
+
+// reconcile sets the state of the ApprovalTask like the approval controller does.
+func (s *SuiteTestApproval) reconcile(name, state string) {
+	task, err := s.server.client().ApprovalTask(s.namespace).Get(context.TODO(), name)
+	s.Require().NoError(err)
+	task.Status.State = state
+	_, err = s.server.client().ApprovalTask(s.namespace).UpdateStatus(context.TODO(), &task)
+	s.Require().NoError(err)
+}
+
+func (s *SuiteTestApproval) Test1Pending() {
+	res, err := s.server.client().Approval(s.namespace).Pending(context.TODO(), metav1.ListOptions{})
+	s.Require().NoError(err)
+	s.Require().Len(res, 2)
+	s.Equal("deploy-approve-run", res[0].PipelineRun)
+	s.Equal("approve", res[0].PipelineTask)
+	s.Equal(2, res[0].Required)
+	s.Equal([]string{"alice", "release-team"}, res[0].Waiting)
+	s.Empty(res[0].Approved)
+}
+
+func (s *SuiteTestApproval) Test2Approve() {
+	svc := s.server.client().Approval(s.namespace)
+	_, err := svc.Approve(context.TODO(), "deploy-approve", approval.Decision{Approver: "bob"})
+	s.Equal(int64(403), err.(*errorx.TektonError).Code)
+
+	task, err := svc.Approve(context.TODO(), "deploy-approve", approval.Decision{Approver: "alice", Message: "looks good"})
+	s.Require().NoError(err)
+	s.Equal(types.ApprovalInputApprove, task.Spec.Approvers[0].Input)
+	s.Equal("looks good", task.Spec.Approvers[0].Message)
+	res, err := svc.Pending(context.TODO(), metav1.ListOptions{})
+	s.Require().NoError(err)
+	s.Equal([]string{"alice"}, res[0].Approved)
+
+	task, err = svc.Approve(context.TODO(), "deploy-approve", approval.Decision{Approver: "carol", Groups: []string{"release-team"}})
+	s.Require().NoError(err)
+	s.Equal([]types.UserDetails{{Name: "carol", Input: types.ApprovalInputApprove}}, task.Spec.Approvers[1].Users)
+	// the status and the CustomRun are left to the approval controller
+	s.Equal(types.ApprovalStatePending, task.Status.State)
+	s.Empty(s.server.requestsMatching("PUT", "/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks/deploy-approve/status"))
+	s.Empty(s.server.requestsMatching("PUT", "/apis/tekton.dev/v1beta1/namespaces/default/customruns"))
+	var audit []approval.AuditRecord
+	s.Require().NoError(json.Unmarshal([]byte(task.Annotations[approval.AuditAnnotation]), &audit))
+	if s.Len(audit, 2) {
+		s.Equal("looks good", audit[0].Message)
+		s.Equal("release-team", audit[1].Group)
+	}
+
+	// enough approvals, decided before the controller reconciled it
+	_, err = svc.Reject(context.TODO(), "deploy-approve", approval.Decision{Approver: "alice"})
+	s.Equal(int64(409), err.(*errorx.TektonError).Code)
+	s.reconcile("deploy-approve", types.ApprovalStateApproved)
+	_, err = svc.Reject(context.TODO(), "deploy-approve", approval.Decision{Approver: "alice"})
+	s.Equal(int64(409), err.(*errorx.TektonError).Code)
+}
+
+func (s *SuiteTestApproval) Test3Reject() {
+	svc := s.server.client().Approval(s.namespace)
+	task, err := svc.Reject(context.TODO(), "hotfix-approve", approval.Decision{Approver: "dave", Groups: []string{"release-team"}, Message: "not during the freeze"})
+	s.Require().NoError(err)
+	s.Equal([]types.UserDetails{{Name: "dave", Input: types.ApprovalInputReject}}, task.Spec.Approvers[1].Users)
+	_, err = svc.Approve(context.TODO(), "hotfix-approve", approval.Decision{Approver: "alice"})
+	s.Equal(int64(409), err.(*errorx.TektonError).Code)
+	s.reconcile("hotfix-approve", types.ApprovalStateRejected)
+
+	res, err := svc.Pending(context.TODO(), metav1.ListOptions{})
+	s.Require().NoError(err)
+	s.Empty(res)
+}
+
+func (s *SuiteTestApproval) Test4Wait() {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+	task, err := s.server.client().Approval(s.namespace).Wait(ctx, "hotfix-approve")
+	s.Require().NoError(err)
+	s.Equal(types.ApprovalStateRejected, task.Status.State)
+}
+
+func (s *SuiteTestApproval) Test5ConcurrentDecisions() {
+	path := "/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks"
+	_, err := s.server.client().ApprovalTask(s.namespace).CreateObject(context.TODO(), &types.ApprovalTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "canary-approve"},
+		Spec: types.ApprovalTaskSpec{
+			Approvers: []types.ApproverDetails{
+				{Name: "alice", Input: types.ApprovalInputPending},
+				{Name: "erin", Input: types.ApprovalInputPending},
+			},
+			NumberOfApprovalsRequired: 2,
+		},
+	})
+	s.Require().NoError(err)
+	// erin answers between the get and the update of alice, whose update conflicts once
+	conflicted := false
+	s.server.handle(path+"/canary-approve", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && !strings.HasSuffix(r.URL.Path, "/status") && !conflicted {
+			conflicted = true
+			task, _ := s.server.get(path, "canary-approve")
+			approvers := task["spec"].(map[string]interface{})["approvers"].([]interface{})
+			approvers[1].(map[string]interface{})["input"] = types.ApprovalInputApprove
+			writeStatus(w, http.StatusConflict, "the object has been modified")
+			return
+		}
+		s.server.passthrough(w, r)
+	})
+	task, err := s.server.client().Approval(s.namespace).Approve(context.TODO(), "canary-approve", approval.Decision{Approver: "alice"})
+	s.Require().NoError(err)
+	s.True(conflicted)
+	s.Equal(types.ApprovalInputApprove, task.Spec.Approvers[0].Input)
+	s.Equal(types.ApprovalInputApprove, task.Spec.Approvers[1].Input)
+	s.Len(s.server.requestsMatching("GET", path+"/canary-approve"), 2)
+}
+
+func (s *SuiteTestApproval) Test6InvalidAudit() {
+	_, err := s.server.client().ApprovalTask(s.namespace).CreateObject(context.TODO(), &types.ApprovalTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken-approve", Annotations: map[string]string{approval.AuditAnnotation: "not json"}},
+		Spec:       types.ApprovalTaskSpec{Approvers: []types.ApproverDetails{{Name: "alice", Input: types.ApprovalInputPending}}},
+	})
+	s.Require().NoError(err)
+	_, err = s.server.client().Approval(s.namespace).Approve(context.TODO(), "broken-approve", approval.Decision{Approver: "alice"})
+	s.ErrorContains(err, "invalid approval.tekton-sdk-go/audit annotation")
+	task, err := s.server.client().ApprovalTask(s.namespace).Get(context.TODO(), "broken-approve")
+	s.Require().NoError(err)
+	s.Equal("not json", task.Annotations[approval.AuditAnnotation])
+}
+
+func (s *SuiteTestApproval) Test7WaitForbidden() {
+	s.server.handle("/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks/secret-approve", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusForbidden, "approvaltasks.openshift-pipelines.org \"secret-approve\" is forbidden")
+	})
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+	start := time.Now()
+	_, err := s.server.client().Approval(s.namespace).Wait(ctx, "secret-approve")
+	s.ErrorContains(err, "is forbidden")
+	s.Less(time.Since(start), 10*time.Second)
+}
+
+func (s *SuiteTestApproval) Test8PendingPages() {
+	for i := 0; i < 600; i++ {
+		s.server.addJSON("/apis/openshift-pipelines.org/v1alpha1/namespaces/default/approvaltasks", types.ApprovalTask{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("bulk-%03d", i), Namespace: s.namespace, Labels: map[string]string{"app": "bulk"}},
+			Spec:       types.ApprovalTaskSpec{Approvers: []types.ApproverDetails{{Name: "alice", Input: types.ApprovalInputPending}}},
+		})
+	}
+	res, err := s.server.client().Approval(s.namespace).Pending(context.TODO(), metav1.ListOptions{LabelSelector: "app=bulk"})
+	s.Require().NoError(err)
+	s.Len(res, 600)
+}
+
+func TestSuiteTestApproval(t *testing.T) {
+	suite.Run(t, new(SuiteTestApproval))
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/builder"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+type SuiteTestBuilder struct {
+	suite.Suite
+}
+
+func (s *SuiteTestBuilder) Test1BuildTask() {
+	task, err := builder.Task("echo").
+		Namespace("default").
+		Label("app", "testtask").
+		Param("message", builder.Default("hello")).
+		Param("flags", builder.ArrayParam()).
+		Step("echo", "alpine", builder.Script("echo $(params.message)")).
+		Step("ls", "alpine", builder.Command("ls"), builder.Args("$(params.flags[*])"), builder.WorkingDir("$(workspaces.source.path)")).
+		Workspace("source", builder.MountPath("/workspace/source")).
+		Result("digest", builder.ResultDescription("image digest")).
+		Build()
+	s.Nil(err)
+	if s.NotNil(task) {
+		s.Equal("Task", task.Kind)
+		s.Equal("testtask", task.Labels["app"])
+		s.Len(task.Spec.Steps, 2)
+		s.Equal("hello", task.Spec.Params[0].Default.StringVal)
+	}
+}
+
+func (s *SuiteTestBuilder) Test2InvalidTask() {
+	_, err := builder.Task("empty").Build()
+	s.ErrorContains(err, "missing field(s): spec.steps")
+
+	_, err = builder.Task("undeclared").Step("echo", "alpine", builder.Script("echo $(params.missing)")).Build()
+	s.ErrorContains(err, "non-existent variable")
+}
+
+func (s *SuiteTestBuilder) Test3BuildPipeline() {
+	pipeline, err := builder.Pipeline("build").
+		Param("repo-url").
+		Param("branch", builder.Default("main")).
+		Workspace("source").
+		Task("clone", "git-clone").TaskParam("url", "$(params.repo-url)").TaskWorkspace("output", "source").
+		TaskSpec("build", builder.Task("build").Step("build", "golang", builder.Script("go build ./..."))).
+		RunAfter("clone").When("$(params.branch)", selection.In, "main").Retries(2).
+		Finally("notify", "send-to-webhook").
+		Result("commit", "$(tasks.clone.results.commit)").
+		Build()
+	s.Nil(err)
+	if s.NotNil(pipeline) {
+		s.Len(pipeline.Spec.Tasks, 2)
+		s.Equal([]string{"clone"}, pipeline.Spec.Tasks[1].RunAfter)
+		s.Equal(2, pipeline.Spec.Tasks[1].Retries)
+		s.Len(pipeline.Spec.Finally, 1)
+	}
+}
+
+func (s *SuiteTestBuilder) Test4InvalidPipeline() {
+	_, err := builder.Pipeline("dangling").Task("build", "golang-build").RunAfter("missing").Build()
+	s.ErrorContains(err, "missing")
+
+	_, err = builder.Pipeline("nocurrent").RunAfter("clone").Task("clone", "git-clone").Build()
+	s.ErrorContains(err, "RunAfter called before Task or Finally")
+}
+
+func (s *SuiteTestBuilder) Test5StepTemplate() {
+	task, err := builder.Task("template").
+		StepTemplate(builder.Env("HOME", "/tekton/home"), builder.VolumeMount("cache", "/cache")).
+		Step("echo", "alpine", builder.Script("echo hello")).
+		Volume(corev1.Volume{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}).
+		Build()
+	s.Require().NoError(err)
+	s.Equal("/tekton/home", task.Spec.StepTemplate.Env[0].Value)
+	s.Equal("/cache", task.Spec.StepTemplate.VolumeMounts[0].MountPath)
+
+	_, err = builder.Task("template").
+		StepTemplate(builder.Script("echo hello"), builder.StepTimeout(time.Minute)).
+		Step("echo", "alpine", builder.Script("echo hello")).
+		Build()
+	s.ErrorContains(err, "StepTemplate cannot set Script, Timeout")
+	_, err = builder.Task("template").StepTemplate(builder.StepActionRef("git-clone")).Step("echo", "alpine").Build()
+	s.ErrorContains(err, "StepTemplate cannot set Ref")
+}
+
+func TestSuiteTestBuilder(t *testing.T) {
+	suite.Run(t, new(SuiteTestBuilder))
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/hongyuxuan/tekton-sdk-go/bundle"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const bundleYaml = `apiVersion: tekton.dev/v1
+kind: Task
+metadata:
+  name: git-clone
+  resourceVersion: "42"
+  uid: 1234
+spec:
+  steps:
+  - name: clone
+    image: alpine/git
+---
+apiVersion: tekton.dev/v1
+kind: Pipeline
+metadata:
+  name: build
+spec:
+  tasks:
+  - name: clone
+    taskRef:
+      name: git-clone
+`
+
+type SuiteTestBundle struct {
+	suite.Suite
+	registry *httptest.Server
+	ref      string
+}
+
+func (s *SuiteTestBundle) SetupSuite() {
+	s.registry = httptest.NewServer(registry.New())
+	s.ref = strings.TrimPrefix(s.registry.URL, "http://") + "/tekton/build:v1"
+}
+
+func (s *SuiteTestBundle) TearDownSuite() {
+	s.registry.Close()
+}
+
+func (s *SuiteTestBundle) Test1Build() {
+	img, err := bundle.BuildYaml(bundleYaml)
+	s.Require().NoError(err)
+	m, err := img.Manifest()
+	s.Require().NoError(err)
+	s.Require().Len(m.Layers, 2)
+	s.Equal(map[string]string{
+		bundle.AnnotationKind:       "task",
+		bundle.AnnotationName:       "git-clone",
+		bundle.AnnotationAPIVersion: "tekton.dev/v1",
+	}, m.Layers[0].Annotations)
+	s.Equal("pipeline", m.Layers[1].Annotations[bundle.AnnotationKind])
+
+	objs, err := bundle.Unpack(img)
+	s.Require().NoError(err)
+	s.Require().Len(objs, 2)
+	s.Equal("git-clone", objs[0].GetName())
+	s.Empty(objs[0].GetResourceVersion())
+	s.Empty(objs[0].GetUID())
+	s.Equal("build", objs[1].GetName())
+
+	task, err := bundle.Get(img, "Task", "git-clone")
+	s.Require().NoError(err)
+	s.Equal("Task", task.GetKind())
+	_, err = bundle.Get(img, "task", "missing")
+	s.Error(err)
+}
+
+func (s *SuiteTestBundle) Test2BuildErrors() {
+	task := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("tekton.dev/v1")
+		obj.SetKind("Task")
+		obj.SetName(name)
+		return obj
+	}
+	_, err := bundle.Build([]*unstructured.Unstructured{task("a"), task("a")})
+	s.ErrorContains(err, "duplicate")
+	_, err = bundle.Build([]*unstructured.Unstructured{task("")})
+	s.ErrorContains(err, "no apiVersion, kind or name")
+	var objs []*unstructured.Unstructured
+	for i := 0; i <= bundle.MaxObjects; i++ {
+		objs = append(objs, task(string(rune('a'+i))))
+	}
+	_, err = bundle.Build(objs)
+	s.ErrorContains(err, "at most 20 objects")
+}
+
+func (s *SuiteTestBundle) Test3PushPull() {
+	img, err := bundle.BuildYaml(bundleYaml)
+	s.Require().NoError(err)
+	digest, err := bundle.Push(context.TODO(), s.ref, img, bundle.Options{Keychain: authn.NewMultiKeychain(), Insecure: true})
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(digest, "sha256:"))
+
+	pulled, err := bundle.Pull(context.TODO(), s.ref, bundle.Options{Keychain: authn.NewMultiKeychain(), Insecure: true})
+	s.Require().NoError(err)
+	out, err := bundle.UnpackYaml(pulled)
+	s.Require().NoError(err)
+	s.Contains(out, "name: git-clone")
+	s.Contains(out, "name: build")
+
+	byDigest, err := bundle.Pull(context.TODO(), strings.Split(s.ref, ":v1")[0]+"@"+digest, bundle.Options{Insecure: true})
+	s.Require().NoError(err)
+	d, err := byDigest.Digest()
+	s.Require().NoError(err)
+	s.Equal(digest, d.String())
+
+	_, err = bundle.Pull(context.TODO(), strings.Replace(s.ref, ":v1", ":v2", 1), bundle.Options{Insecure: true})
+	s.ErrorContains(err, "cannot pull bundle")
+}
+
+func (s *SuiteTestBundle) Test4Layout() {
+	path := filepath.Join(s.T().TempDir(), "oci")
+	img, err := bundle.BuildYaml(bundleYaml)
+	s.Require().NoError(err)
+	digest, err := bundle.WriteLayout(path, "build:v1", img)
+	s.Require().NoError(err)
+
+	other, err := bundle.BuildYaml(strings.Split(bundleYaml, "---\n")[0])
+	s.Require().NoError(err)
+	_, err = bundle.WriteLayout(path, "git-clone:v1", other)
+	s.Require().NoError(err)
+	// writing a name again replaces the image
+	_, err = bundle.WriteLayout(path, "git-clone:v1", other)
+	s.Require().NoError(err)
+
+	read, err := bundle.ReadLayout(path, "build:v1")
+	s.Require().NoError(err)
+	d, err := read.Digest()
+	s.Require().NoError(err)
+	s.Equal(digest, d.String())
+	objs, err := bundle.Unpack(read)
+	s.Require().NoError(err)
+	s.Len(objs, 2)
+
+	_, err = bundle.ReadLayout(path, "")
+	s.ErrorContains(err, "2 images")
+	_, err = bundle.ReadLayout(path, "missing:v1")
+	s.ErrorContains(err, "no bundle missing:v1")
+	_, err = bundle.ReadLayout(filepath.Join(path, "missing"), "")
+	s.ErrorContains(err, "not an OCI image layout")
+}
+
+func TestSuiteTestBundle(t *testing.T) {
+	suite.Run(t, new(SuiteTestBundle))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tekton "github.com/hongyuxuan/tekton-sdk-go"
+	"github.com/hongyuxuan/tekton-sdk-go/core/option"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/client-go/rest"
+)
+
+const multiContextKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com:6443
+- name: production
+  cluster:
+    server: https://production.example.com:6443
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: admin
+- name: production
+  context:
+    cluster: production
+    user: admin
+current-context: staging
+users:
+- name: admin
+  user:
+    token: admin-token
+`
+
+type SuiteTestClient struct {
+	suite.Suite
+}
+
+func (s *SuiteTestClient) Test1KubeconfigBytes() {
+	client := tekton.NewClient(option.WithKubeconfigBytes([]byte(multiContextKubeconfig)))
+	s.Equal("https://staging.example.com:6443", client.Config.Httpclient.BaseURL)
+}
+
+func (s *SuiteTestClient) Test2Context() {
+	client := tekton.NewClient(
+		option.WithKubeconfigBytes([]byte(multiContextKubeconfig)),
+		option.WithContext("production"),
+	)
+	s.Equal("https://production.example.com:6443", client.Config.Httpclient.BaseURL)
+}
+
+func (s *SuiteTestClient) Test3RestConfigAndBaseUrl() {
+	client := tekton.NewClient(
+		option.WithRestConfig(&rest.Config{Host: "https://in-memory.example.com", BearerToken: "token"}),
+		option.WithBaseUrl("https://proxy.example.com"),
+	)
+	s.Equal("https://proxy.example.com", client.Config.Httpclient.BaseURL)
+}
+
+func (s *SuiteTestClient) Test4KubeconfigEnv() {
+	dir := s.T().TempDir()
+	path := filepath.Join(dir, "config")
+	s.Require().NoError(os.WriteFile(path, []byte(multiContextKubeconfig), 0600))
+	s.T().Setenv("KUBECONFIG", filepath.Join(dir, "missing")+string(os.PathListSeparator)+path)
+
+	client := tekton.NewClient(option.WithKubeconfigEnv(), option.WithContext("production"))
+	s.Equal("https://production.example.com:6443", client.Config.Httpclient.BaseURL)
+}
+
+func (s *SuiteTestClient) Test5NotInCluster() {
+	s.T().Setenv("KUBERNETES_SERVICE_HOST", "")
+	s.PanicsWithError("no kubeconfig given and not running in a cluster: unable to load in-cluster configuration, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be defined", func() {
+		tekton.NewClient()
+	})
+}
+
+func TestSuiteTestClient(t *testing.T) {
+	suite.Run(t, new(SuiteTestClient))
+}
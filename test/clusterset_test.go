@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tekton "github.com/hongyuxuan/tekton-sdk-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteTestClusterSet struct {
+	suite.Suite
+	server *httptest.Server
+	dir    string
+}
+
+func (s *SuiteTestClusterSet) SetupSuite() {
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/readyz":
+			fmt.Fprint(w, "ok")
+		case "/version":
+			fmt.Fprint(w, `{"major":"1","minor":"29","gitVersion":"v1.29.6"}`)
+		case "/apis/tekton.dev/v1":
+			fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"tekton.dev/v1","resources":[{"name":"pipelines","namespaced":true,"kind":"Pipeline","verbs":["get","list"]}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	s.dir = s.T().TempDir()
+	for _, name := range []string{"staging", "production"} {
+		kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: %[2]s
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s
+current-context: %[1]s
+users:
+- name: %[1]s
+  user:
+    token: %[1]s-token
+`, name, s.server.URL)
+		s.Require().NoError(os.WriteFile(filepath.Join(s.dir, name+".yaml"), []byte(kubeconfig), 0600))
+	}
+}
+
+func (s *SuiteTestClusterSet) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestClusterSet) Test1LoadFromDir() {
+	cs, err := tekton.NewClusterSetFromDir(s.dir)
+	s.Nil(err)
+	s.Equal([]string{"production", "staging"}, cs.Names())
+	client, ok := cs.Cluster("staging")
+	if s.True(ok) {
+		s.Equal(filepath.Join(s.dir, "staging.yaml"), client.Config.Kubeconfig)
+	}
+}
+
+func (s *SuiteTestClusterSet) Test2ListAcross() {
+	cs, err := tekton.NewClusterSetFromDir(s.dir)
+	s.Require().NoError(err)
+	res := tekton.ListAcross(context.TODO(), cs, func(ctx context.Context, c *tekton.Client) ([]string, error) {
+		if c.Config.Kubeconfig == filepath.Join(s.dir, "production.yaml") {
+			return nil, fmt.Errorf("unreachable")
+		}
+		return []string{c.Config.Kubeconfig}, nil
+	})
+	if s.Len(res, 2) {
+		s.Equal("production", res[0].Cluster)
+		s.NotNil(res[0].Err)
+		s.Equal("staging", res[1].Cluster)
+		s.Nil(res[1].Err)
+		s.Len(res[1].Items, 1)
+	}
+}
+
+func (s *SuiteTestClusterSet) Test3HealthCheck() {
+	cs, err := tekton.NewClusterSetFromDir(s.dir)
+	s.Require().NoError(err)
+	for _, health := range cs.HealthCheck(context.TODO()) {
+		s.Nil(health.Err)
+		s.True(health.Healthy)
+		s.Equal("v1.29.6", health.Version)
+		s.Equal([]string{"tekton.dev/v1"}, health.TektonAPIs)
+	}
+}
+
+func TestSuiteTestClusterSet(t *testing.T) {
+	suite.Run(t, new(SuiteTestClusterSet))
+}
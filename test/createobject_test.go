@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	tekton "github.com/hongyuxuan/tekton-sdk-go"
+	"github.com/hongyuxuan/tekton-sdk-go/builder"
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SuiteTestCreateObject struct {
+	suite.Suite
+	server    *fakeAPIServer
+	client    *tekton.Client
+	namespace string
+}
+
+func (s *SuiteTestCreateObject) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.client = s.server.client()
+	s.namespace = "default"
+}
+
+func (s *SuiteTestCreateObject) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestCreateObject) Test1CreatePipeline() {
+	pipeline, err := builder.Pipeline("testpipeline").Task("clone", "git-clone").Build()
+	s.Require().NoError(err)
+	res, err := s.client.Pipeline(s.namespace).CreateObject(context.TODO(), pipeline)
+	s.Nil(err)
+	s.Equal("testpipeline", res.Name)
+	s.Equal(s.namespace, res.Namespace)
+	s.NotEmpty(res.UID)
+	s.Equal("git-clone", res.Spec.Tasks[0].TaskRef.Name)
+}
+
+func (s *SuiteTestCreateObject) Test2CreatePipelineRunWithGenerateName() {
+	res, err := s.client.PipelineRun(s.namespace).CreateObject(context.TODO(), &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "testpipeline-run-"},
+		Spec:       tektonv1.PipelineRunSpec{PipelineRef: &tektonv1.PipelineRef{Name: "testpipeline"}},
+	})
+	s.Nil(err)
+	s.Regexp("^testpipeline-run-.+", res.Name)
+	s.NotEmpty(res.ResourceVersion)
+}
+
+func (s *SuiteTestCreateObject) Test3CreateTriggerBinding() {
+	res, err := s.client.TriggerBinding(s.namespace).CreateObject(context.TODO(), &tektonv1beta1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "testtriggerbinding"},
+		Spec: tektonv1beta1.TriggerBindingSpec{Params: []tektonv1beta1.Param{
+			{Name: "revision", Value: "$(body.ref)"},
+		}},
+	})
+	s.Nil(err)
+	s.Equal("$(body.ref)", res.Spec.Params[0].Value)
+}
+
+func (s *SuiteTestCreateObject) Test4CreateConflict() {
+	task, err := builder.Task("testtask").Step("echo", "alpine", builder.Script("echo")).Build()
+	s.Require().NoError(err)
+	_, err = s.client.Task(s.namespace).CreateObject(context.TODO(), task)
+	s.Nil(err)
+	_, err = s.client.Task(s.namespace).CreateObject(context.TODO(), task)
+	if s.IsType(&errorx.TektonError{}, err) {
+		s.Equal(int64(409), err.(*errorx.TektonError).Code)
+	}
+}
+
+func TestSuiteTestCreateObject(t *testing.T) {
+	suite.Run(t, new(SuiteTestCreateObject))
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type SuiteTestCustomRun struct {
+	suite.Suite
+	server    *fakeAPIServer
+	namespace string
+}
+
+func (s *SuiteTestCustomRun) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+}
+
+func (s *SuiteTestCustomRun) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestCustomRun) Test1CreateObject() {
+	res, err := s.server.client().CustomRun(s.namespace).CreateObject(context.TODO(), &pipelinev1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "wait-x7k2p", Labels: map[string]string{"app": "testcustomrun"}},
+		Spec: pipelinev1beta1.CustomRunSpec{
+			CustomRef: &pipelinev1beta1.TaskRef{APIVersion: "wait.testing.tekton.dev/v1beta1", Kind: "Wait"},
+			Params:    pipelinev1beta1.Params{{Name: "duration", Value: *pipelinev1beta1.NewStructuredValues("10s")}},
+		},
+	})
+	s.Require().NoError(err)
+	s.Equal("CustomRun", res.Kind)
+	s.Len(s.server.requestsMatching("POST", "/apis/tekton.dev/v1beta1/namespaces/default/customruns"), 1)
+
+	list, err := s.server.client().CustomRun(s.namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "app=testcustomrun"})
+	s.Require().NoError(err)
+	s.Len(list, 1)
+}
+
+func (s *SuiteTestCustomRun) Test2Status() {
+	svc := s.server.client().CustomRun(s.namespace)
+	res, err := svc.MarkRunning(context.TODO(), "wait-x7k2p", string(pipelinev1beta1.CustomRunReasonRunning), "waiting 10s")
+	s.Require().NoError(err)
+	s.True(res.HasStarted())
+	s.False(res.IsDone())
+	s.Len(s.server.requestsMatching("PUT", "/apis/tekton.dev/v1beta1/namespaces/default/customruns/wait-x7k2p/status"), 1)
+
+	_, err = svc.SetResults(context.TODO(), "wait-x7k2p", pipelinev1beta1.CustomRunResult{Name: "waited", Value: "5s"})
+	s.Require().NoError(err)
+	_, err = svc.SetResults(context.TODO(), "wait-x7k2p",
+		pipelinev1beta1.CustomRunResult{Name: "waited", Value: "10s"},
+		pipelinev1beta1.CustomRunResult{Name: "node", Value: "worker-1"})
+	s.Require().NoError(err)
+	res, err = svc.MarkSucceeded(context.TODO(), "wait-x7k2p", string(pipelinev1beta1.CustomRunReasonSuccessful), "waited 10s")
+	s.Require().NoError(err)
+	s.True(res.IsSuccessful())
+	s.NotNil(res.Status.CompletionTime)
+	s.Equal([]pipelinev1beta1.CustomRunResult{{Name: "waited", Value: "10s"}, {Name: "node", Value: "worker-1"}}, res.Status.Results)
+
+	res, err = svc.SetCondition(context.TODO(), "wait-x7k2p", apis.Condition{Type: "Approved", Status: corev1.ConditionTrue})
+	s.Require().NoError(err)
+	s.Len(res.Status.Conditions, 2)
+}
+
+func (s *SuiteTestCustomRun) Test3Cancel() {
+	res, err := s.server.client().CustomRun(s.namespace).Cancel(context.TODO(), "wait-x7k2p", "no longer needed")
+	s.Require().NoError(err)
+	s.True(res.IsCancelled())
+	s.Equal(pipelinev1beta1.CustomRunSpecStatusMessage("no longer needed"), res.Spec.StatusMessage)
+	s.Equal("10s", res.Spec.Params[0].Value.StringVal)
+	patches := s.server.requestsMatching("PATCH", "/apis/tekton.dev/v1beta1/namespaces/default/customruns/wait-x7k2p")
+	s.Len(patches, 1)
+}
+
+func (s *SuiteTestCustomRun) Test4Delete() {
+	s.Require().NoError(s.server.client().CustomRun(s.namespace).Delete(context.TODO(), "wait-x7k2p"))
+	_, err := s.server.client().CustomRun(s.namespace).Get(context.TODO(), "wait-x7k2p")
+	s.Error(err)
+}
+
+func TestSuiteTestCustomRun(t *testing.T) {
+	suite.Run(t, new(SuiteTestCustomRun))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type SuiteTestDelete struct {
+	suite.Suite
+	server    *fakeAPIServer
+	namespace string
+}
+
+func (s *SuiteTestDelete) SetupTest() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+	for _, name := range []string{"build", "deploy", "lint"} {
+		app := "testpipeline"
+		if name == "lint" {
+			app = "other"
+		}
+		s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelines", tektonv1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app": app}},
+		})
+	}
+	s.server.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/default/triggerbindings", tektonv1beta1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "testtriggerbinding", Labels: map[string]string{"app": "testtriggerbinding"}},
+	})
+}
+
+func (s *SuiteTestDelete) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *SuiteTestDelete) Test1DeleteWithOptions() {
+	uid := types.UID("00000000-0000-0000-0000-000000000001")
+	policy := metav1.DeletePropagationForeground
+	err := s.server.client().Pipeline(s.namespace).Delete(context.TODO(), "build", metav1.DeleteOptions{
+		PropagationPolicy:  &policy,
+		GracePeriodSeconds: new(int64),
+		Preconditions:      &metav1.Preconditions{UID: &uid},
+		DryRun:             []string{metav1.DryRunAll},
+	})
+	s.Nil(err)
+	reqs := s.server.requestsMatching("DELETE", "/apis/tekton.dev/v1/namespaces/default/pipelines/build")
+	if s.Len(reqs, 1) {
+		s.JSONEq(`{
+			"kind": "DeleteOptions",
+			"apiVersion": "v1",
+			"gracePeriodSeconds": 0,
+			"preconditions": {"uid": "00000000-0000-0000-0000-000000000001"},
+			"propagationPolicy": "Foreground",
+			"dryRun": ["All"]
+		}`, reqs[0].Body)
+	}
+}
+
+func (s *SuiteTestDelete) Test2DeleteWithSeveralOptions() {
+	policy := metav1.DeletePropagationOrphan
+	err := s.server.client().Pipeline(s.namespace).Delete(context.TODO(), "build", metav1.DeleteOptions{}, metav1.DeleteOptions{PropagationPolicy: &policy})
+	s.ErrorContains(err, "a delete takes a single DeleteOptions, got 2")
+	s.Empty(s.server.requestsMatching("DELETE", "/apis/tekton.dev/v1/namespaces/default/pipelines/build"))
+}
+
+func (s *SuiteTestDelete) Test3DeleteWithoutOptions() {
+	s.Nil(s.server.client().Pipeline(s.namespace).Delete(context.TODO(), "build"))
+	reqs := s.server.requestsMatching("DELETE", "/apis/tekton.dev/v1/namespaces/default/pipelines/build")
+	if s.Len(reqs, 1) {
+		s.Empty(reqs[0].Body)
+	}
+	s.Equal([]string{"deploy", "lint"}, s.server.names("/apis/tekton.dev/v1/namespaces/default/pipelines"))
+}
+
+func (s *SuiteTestDelete) Test4DeleteCollection() {
+	err := s.server.client().Pipeline(s.namespace).DeleteCollection(context.TODO(), metav1.ListOptions{LabelSelector: "app=testpipeline"})
+	s.Nil(err)
+	s.Equal([]string{"lint"}, s.server.names("/apis/tekton.dev/v1/namespaces/default/pipelines"))
+
+	policy := metav1.DeletePropagationOrphan
+	err = s.server.client().TriggerBinding(s.namespace).DeleteCollection(context.TODO(), metav1.ListOptions{LabelSelector: "app=testtriggerbinding"}, metav1.DeleteOptions{PropagationPolicy: &policy})
+	s.Nil(err)
+	reqs := s.server.requestsMatching("DELETE", "/apis/triggers.tekton.dev/v1beta1/namespaces/default/triggerbindings")
+	if s.Len(reqs, 1) {
+		s.Equal("labelSelector=app%3Dtesttriggerbinding", reqs[0].Query)
+		s.Contains(reqs[0].Body, `"propagationPolicy":"Orphan"`)
+	}
+}
+
+func TestSuiteTestDelete(t *testing.T) {
+	suite.Run(t, new(SuiteTestDelete))
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+type SuiteTestEventListenerStatus struct {
+	suite.Suite
+	server    *fakeAPIServer
+	name      string
+	namespace string
+}
+
+func (s *SuiteTestEventListenerStatus) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.name = "testeventlistener"
+	s.namespace = "default"
+
+	url, _ := apis.ParseURL("http://el-testeventlistener.default.svc.cluster.local:8080")
+	el := tektonv1beta1.EventListener{ObjectMeta: metav1.ObjectMeta{Name: s.name}}
+	el.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+	el.Status.Address = &duckv1beta1.Addressable{URL: url}
+	el.Status.Configuration.GeneratedResourceName = "el-" + s.name
+	s.server.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/default/eventlisteners", el)
+
+	pending := tektonv1beta1.EventListener{ObjectMeta: metav1.ObjectMeta{Name: "pending"}}
+	pending.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionFalse, Reason: "DeploymentNotReady", Message: "0/1 available"}}
+	s.server.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/default/eventlisteners", pending)
+
+	replicas := int32(2)
+	s.server.addJSON("/apis/apps/v1/namespaces/default/deployments", appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "el-" + s.name},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"eventlistener": s.name}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1, AvailableReplicas: 1},
+	})
+	s.server.addJSON("/api/v1/namespaces/default/services", corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "el-" + s.name},
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.96.0.10", Ports: []corev1.ServicePort{
+			{Name: "http-listener", Port: 8080},
+		}},
+	})
+	for name, ready := range map[string]bool{"el-testeventlistener-a": true, "el-testeventlistener-b": false} {
+		pod := corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"eventlistener": s.name}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		} else {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				RestartCount: 3,
+				State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}
+		}
+		s.server.addJSON("/api/v1/namespaces/default/pods", pod)
+	}
+}
+
+func (s *SuiteTestEventListenerStatus) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestEventListenerStatus) Test1Address() {
+	url, err := s.server.client().EventListener(s.namespace).Address(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.Equal("http://el-testeventlistener.default.svc.cluster.local:8080", url)
+
+	url, err = s.server.client().EventListener(s.namespace).Address(context.TODO(), "pending")
+	s.Require().NoError(err)
+	s.Empty(url)
+}
+
+func (s *SuiteTestEventListenerStatus) Test2WaitReady() {
+	el, err := s.server.client().EventListener(s.namespace).WaitReady(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.Equal(s.name, el.Name)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	_, err = s.server.client().EventListener(s.namespace).WaitReady(ctx, "pending")
+	s.ErrorContains(err, "eventlistener pending not ready: DeploymentNotReady: 0/1 available")
+}
+
+func (s *SuiteTestEventListenerStatus) Test3Status() {
+	st, err := s.server.client().EventListener(s.namespace).Status(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.True(st.Ready)
+	if s.NotNil(st.Deployment) {
+		s.Equal(int32(2), st.Deployment.Replicas)
+		s.Equal(int32(1), st.Deployment.ReadyReplicas)
+		s.False(st.Deployment.Ready)
+	}
+	if s.NotNil(st.Service) {
+		s.Equal("10.96.0.10", st.Service.ClusterIP)
+	}
+	if s.Len(st.Pods, 2) {
+		s.True(st.Pods[0].Ready)
+		s.False(st.Pods[1].Ready)
+		s.Equal("CrashLoopBackOff", st.Pods[1].Reason)
+		s.Equal(int32(3), st.Pods[1].Restarts)
+	}
+
+	st, err = s.server.client().EventListener(s.namespace).Status(context.TODO(), "pending")
+	s.Require().NoError(err)
+	s.False(st.Ready)
+	s.Nil(st.Deployment)
+	s.Nil(st.Service)
+	s.Empty(st.Pods)
+}
+
+func (s *SuiteTestEventListenerStatus) Test4WaitReadyForbidden() {
+	s.server.handle("/apis/triggers.tekton.dev/v1beta1/namespaces/default/eventlisteners/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusForbidden, "eventlisteners.triggers.tekton.dev \"forbidden\" is forbidden")
+	})
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+	start := time.Now()
+	_, err := s.server.client().EventListener(s.namespace).WaitReady(ctx, "forbidden")
+	s.ErrorContains(err, "is forbidden")
+	s.Less(time.Since(start), 10*time.Second)
+
+	// a listener not created yet is waited for
+	ctx, cancel = context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	_, err = s.server.client().EventListener(s.namespace).WaitReady(ctx, "missing")
+	s.ErrorContains(err, "eventlistener missing not ready")
+}
+
+func TestSuiteTestEventListenerStatus(t *testing.T) {
+	suite.Run(t, new(SuiteTestEventListenerStatus))
+}
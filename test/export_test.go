@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	tekton "github.com/hongyuxuan/tekton-sdk-go"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SuiteTestExport struct {
+	suite.Suite
+	staging    *fakeAPIServer
+	production *fakeAPIServer
+}
+
+func (s *SuiteTestExport) SetupTest() {
+	s.staging = newFakeAPIServer()
+	s.production = newFakeAPIServer()
+	labels := map[string]string{"app": "testexport"}
+	s.staging.addJSON("/apis/tekton.dev/v1/namespaces/staging/tasks", tektonv1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Labels: labels, Annotations: map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		}},
+		Spec: tektonv1.TaskSpec{Steps: []tektonv1.Step{{Name: "build", Image: "alpine"}}},
+	})
+	s.staging.addJSON("/apis/tekton.dev/v1/namespaces/staging/pipelines", tektonv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Labels: labels},
+		Spec: tektonv1.PipelineSpec{Tasks: []tektonv1.PipelineTask{
+			{Name: "build", TaskRef: &tektonv1.TaskRef{Name: "build"}},
+		}},
+	})
+	s.staging.addJSON("/apis/tekton.dev/v1/namespaces/staging/pipelines", tektonv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+	})
+	s.staging.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/staging/triggerbindings", tektonv1beta1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "git", Labels: labels},
+	})
+	s.production.addJSON("/apis/tekton.dev/v1/namespaces/production/tasks", tektonv1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build"},
+	})
+}
+
+func (s *SuiteTestExport) TearDownTest() {
+	s.staging.Close()
+	s.production.Close()
+}
+
+func (s *SuiteTestExport) Test1Export() {
+	res, err := s.staging.client().Export(context.TODO(), "staging", nil, "app=testexport")
+	s.Require().NoError(err)
+	s.Empty(res.Skipped)
+	bundle := res.Bundle
+	s.Contains(bundle, "kind: Task\n")
+	s.Contains(bundle, "apiVersion: triggers.tekton.dev/v1beta1\n")
+	s.NotContains(bundle, "uid:")
+	s.NotContains(bundle, "resourceVersion:")
+	s.NotContains(bundle, "creationTimestamp:")
+	s.NotContains(bundle, "last-applied-configuration")
+	s.NotContains(bundle, "name: other")
+	s.Regexp(`(?s)kind: Task\n.*---\n.*kind: Pipeline\n.*---\n.*kind: TriggerBinding\n`, bundle)
+
+	again, err := s.staging.client().Export(context.TODO(), "staging", nil, "app=testexport")
+	s.Require().NoError(err)
+	s.Equal(bundle, again.Bundle)
+
+	_, err = s.staging.client().Export(context.TODO(), "staging", []string{"Secret"}, "")
+	s.ErrorContains(err, "unsupported kind Secret")
+}
+
+func (s *SuiteTestExport) Test2Import() {
+	exported, err := s.staging.client().Export(context.TODO(), "staging", []string{"Pipeline", "Task"}, "app=testexport")
+	s.Require().NoError(err)
+	bundle := exported.Bundle
+
+	res, err := s.production.client().Import(context.TODO(), "production", bundle, tekton.ImportOptions{})
+	s.Require().NoError(err)
+	s.Equal([]string{"Task/build"}, res.Skipped)
+	s.Equal([]string{"Pipeline/deploy"}, res.Created)
+	pipeline, ok := s.production.get("/apis/tekton.dev/v1/namespaces/production/pipelines", "deploy")
+	if s.True(ok) {
+		s.Equal("production", pipeline["metadata"].(map[string]interface{})["namespace"])
+	}
+
+	res, err = s.production.client().Import(context.TODO(), "production", bundle, tekton.ImportOptions{Overwrite: true})
+	s.Require().NoError(err)
+	s.Equal([]string{"Task/build", "Pipeline/deploy"}, res.Updated)
+	task, _ := s.production.get("/apis/tekton.dev/v1/namespaces/production/tasks", "build")
+	s.NotNil(task["spec"])
+	for _, r := range s.production.requestsMatching("PUT", "/apis/tekton.dev/v1/namespaces/production/") {
+		s.Contains(r.Body, `"resourceVersion"`)
+		s.Contains(r.Body, `"namespace":"production"`)
+	}
+}
+
+func (s *SuiteTestExport) Test3ImportInvalidBundle() {
+	_, err := s.production.client().Import(context.TODO(), "production", "apiVersion: v1\nkind: Secret\nmetadata:\n  name: token\n", tekton.ImportOptions{})
+	s.ErrorContains(err, "unsupported kind v1 Secret of token")
+	_, err = s.production.client().Import(context.TODO(), "production", "kind: Task\n", tekton.ImportOptions{})
+	s.ErrorContains(err, "document 0 has no apiVersion")
+}
+
+func (s *SuiteTestExport) Test4ExportWithoutTriggers() {
+	// a cluster without Tekton Triggers, and a token that cannot list StepActions
+	s.staging.handle("/apis/triggers.tekton.dev/", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusNotFound, "the server could not find the requested resource")
+	})
+	s.staging.handle("/apis/tekton.dev/v1beta1/namespaces/staging/stepactions", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusForbidden, "stepactions.tekton.dev is forbidden")
+	})
+	res, err := s.staging.client().Export(context.TODO(), "staging", nil, "app=testexport")
+	s.Require().NoError(err)
+	s.Contains(res.Bundle, "kind: Pipeline\n")
+	s.NotContains(res.Bundle, "kind: TriggerBinding\n")
+	s.ElementsMatch([]string{"StepAction", "TriggerBinding", "TriggerTemplate", "Interceptor", "Trigger", "EventListener"}, lo.Keys(res.Skipped))
+	s.ErrorContains(res.Skipped["StepAction"], "forbidden")
+
+	_, err = s.staging.client().Export(context.TODO(), "staging", []string{"Task", "EventListener"}, "")
+	s.ErrorContains(err, "could not find the requested resource")
+}
+
+func TestSuiteTestExport(t *testing.T) {
+	suite.Run(t, new(SuiteTestExport))
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tekton "github.com/hongyuxuan/tekton-sdk-go"
+	"github.com/hongyuxuan/tekton-sdk-go/core/option"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// fakeAPIServer is a minimal in-memory Kubernetes API server for the tests that do not need
+// a real cluster: it stores objects by collection path and supports get, list with label
+// selectors, create with generateName, update and delete.
+type fakeAPIServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	objects  map[string]map[string]map[string]interface{}
+	requests []fakeRequest
+	handlers map[string]http.HandlerFunc
+	seq      int
+}
+
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+func newFakeAPIServer() *fakeAPIServer {
+	f := &fakeAPIServer{objects: make(map[string]map[string]map[string]interface{})}
+	f.Server = httptest.NewTLSServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+func (f *fakeAPIServer) kubeconfig() string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: fake
+  cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+contexts:
+- name: fake
+  context:
+    cluster: fake
+    user: fake
+current-context: fake
+users:
+- name: fake
+  user:
+    token: fake-token
+`, f.URL)
+}
+
+func (f *fakeAPIServer) client() *tekton.Client {
+	return tekton.NewClient(option.WithKubeconfigBytes([]byte(f.kubeconfig())))
+}
+
+// add stores obj in the collection at path, e.g. /apis/tekton.dev/v1/namespaces/default/pipelineruns.
+func (f *fakeAPIServer) add(path string, obj map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.store(path, obj)
+}
+
+// addJSON is add for an object literal or a typed object.
+func (f *fakeAPIServer) addJSON(path string, obj interface{}) {
+	var raw []byte
+	switch o := obj.(type) {
+	case string:
+		raw = []byte(o)
+	default:
+		raw, _ = json.Marshal(o)
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &m); err != nil {
+		panic(err)
+	}
+	f.add(path, m)
+}
+
+// handle serves the paths under prefix with handler instead of the object store, e.g. a service proxy.
+func (f *fakeAPIServer) handle(prefix string, handler http.HandlerFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.handlers == nil {
+		f.handlers = make(map[string]http.HandlerFunc)
+	}
+	f.handlers[prefix] = handler
+}
+
+func (f *fakeAPIServer) get(path, name string) (map[string]interface{}, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	obj, ok := f.objects[path][name]
+	return obj, ok
+}
+
+func (f *fakeAPIServer) names(path string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.objects[path]))
+	for name := range f.objects[path] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *fakeAPIServer) requestsMatching(method, pathPrefix string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var res []fakeRequest
+	for _, r := range f.requests {
+		if r.Method == method && strings.HasPrefix(r.Path, pathPrefix) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func (f *fakeAPIServer) store(path string, obj map[string]interface{}) map[string]interface{} {
+	f.seq++
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+		obj["metadata"] = metadata
+	}
+	if name, _ := metadata["name"].(string); name == "" {
+		metadata["name"] = fmt.Sprintf("%s%05d", metadata["generateName"], f.seq)
+	}
+	if _, ok := metadata["uid"]; !ok {
+		metadata["uid"] = fmt.Sprintf("00000000-0000-0000-0000-%012d", f.seq)
+	}
+	if _, ok := metadata["creationTimestamp"]; !ok {
+		metadata["creationTimestamp"] = time.Now().UTC().Format(time.RFC3339)
+	}
+	metadata["resourceVersion"] = fmt.Sprintf("%d", f.seq)
+	if parts := strings.Split(path, "/namespaces/"); len(parts) == 2 {
+		metadata["namespace"] = strings.Split(parts[1], "/")[0]
+	}
+	if f.objects[path] == nil {
+		f.objects[path] = make(map[string]map[string]interface{})
+	}
+	f.objects[path][metadata["name"].(string)] = obj
+	return obj
+}
+
+func (f *fakeAPIServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	f.requests = append(f.requests, fakeRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body)})
+	for prefix, handler := range f.handlers {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			f.mu.Unlock()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			handler(w, r)
+			return
+		}
+	}
+	f.mu.Unlock()
+	f.serveStore(w, r, body)
+}
+
+// passthrough serves r from the object store, for a handler that only intercepts some requests.
+func (f *fakeAPIServer) passthrough(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.serveStore(w, r, body)
+}
+
+func (f *fakeAPIServer) serveStore(w http.ResponseWriter, r *http.Request, body []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	collection, name := f.split(r.URL.Path)
+	switch {
+	case r.Method == http.MethodGet && name == "" && r.URL.Query().Get("watch") == "true":
+		f.writeWatch(w, collection, r.URL.Query().Get("labelSelector"))
+	case r.Method == http.MethodGet && name == "":
+		f.writeList(w, collection, r.URL.Query())
+	case r.Method == http.MethodGet:
+		obj, ok := f.objects[collection][name]
+		if !ok {
+			writeStatus(w, http.StatusNotFound, fmt.Sprintf("%q not found", name))
+			return
+		}
+		json.NewEncoder(w).Encode(obj)
+	case r.Method == http.MethodPost:
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal(body, &obj); err != nil {
+			writeStatus(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if metadata, _ := obj["metadata"].(map[string]interface{}); metadata != nil {
+			if n, _ := metadata["name"].(string); n != "" {
+				if _, exists := f.objects[collection][n]; exists {
+					writeStatus(w, http.StatusConflict, fmt.Sprintf("%q already exists", n))
+					return
+				}
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(f.store(collection, obj))
+	case r.Method == http.MethodPut || r.Method == http.MethodPatch:
+		existing, ok := f.objects[collection][name]
+		if !ok {
+			writeStatus(w, http.StatusNotFound, fmt.Sprintf("%q not found", name))
+			return
+		}
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal(body, &obj); err != nil {
+			writeStatus(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if r.Method == http.MethodPatch {
+			obj = mergePatch(existing, obj)
+		}
+		json.NewEncoder(w).Encode(f.store(collection, obj))
+	case r.Method == http.MethodDelete && name == "":
+		selector, _ := labels.Parse(r.URL.Query().Get("labelSelector"))
+		for n, obj := range f.objects[collection] {
+			if selector.Matches(objectLabels(obj)) {
+				delete(f.objects[collection], n)
+			}
+		}
+		writeStatus(w, http.StatusOK, "")
+	case r.Method == http.MethodDelete:
+		obj, ok := f.objects[collection][name]
+		if !ok {
+			writeStatus(w, http.StatusNotFound, fmt.Sprintf("%q not found", name))
+			return
+		}
+		delete(f.objects[collection], name)
+		json.NewEncoder(w).Encode(obj)
+	default:
+		writeStatus(w, http.StatusMethodNotAllowed, r.Method)
+	}
+}
+
+// split returns the collection path and object name of an API path, dropping any subresource.
+func (f *fakeAPIServer) split(path string) (collection, name string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// api/v1/... has one segment less than apis/group/version/...
+	base := 3
+	if parts[0] == "api" {
+		base = 2
+	}
+	if len(parts) > base && parts[base] == "namespaces" && len(parts) > base+2 {
+		base += 2
+	}
+	if len(parts) <= base+1 {
+		return "/" + strings.Join(parts, "/"), ""
+	}
+	return "/" + strings.Join(parts[:base+1], "/"), parts[base+1]
+}
+
+// writeList writes the objects matching the labelSelector query param ordered by name, a page of
+// limit objects at a time with the offset of the next page as continue token.
+func (f *fakeAPIServer) writeList(w http.ResponseWriter, collection string, query url.Values) {
+	selector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	names := make([]string, 0)
+	for name, obj := range f.objects[collection] {
+		if selector.Matches(objectLabels(obj)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	metadata := map[string]interface{}{"resourceVersion": fmt.Sprintf("%d", f.seq)}
+	offset, _ := strconv.Atoi(query.Get("continue"))
+	names = names[min(offset, len(names)):]
+	if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && limit < len(names) {
+		names = names[:limit]
+		metadata["continue"] = fmt.Sprintf("%d", offset+limit)
+	}
+	items := make([]interface{}, 0, len(names))
+	kind := "List"
+	for _, name := range names {
+		items = append(items, f.objects[collection][name])
+		if k, _ := f.objects[collection][name]["kind"].(string); k != "" {
+			kind = k + "List"
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       kind,
+		"metadata":   metadata,
+		"items":      items,
+	})
+}
+
+// writeWatch streams an ADDED event for every object matching labelSelector, then ends the watch.
+func (f *fakeAPIServer) writeWatch(w http.ResponseWriter, collection, labelSelector string) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	names := make([]string, 0)
+	for name, obj := range f.objects[collection] {
+		if selector.Matches(objectLabels(obj)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	enc := json.NewEncoder(w)
+	for _, name := range names {
+		enc.Encode(map[string]interface{}{"type": "ADDED", "object": f.objects[collection][name]})
+	}
+	if selector.Empty() {
+		enc.Encode(map[string]interface{}{"type": "ERROR", "object": map[string]interface{}{
+			"kind": "Status", "apiVersion": "v1", "code": http.StatusGone, "message": "too old resource version",
+		}})
+	}
+}
+
+func objectLabels(obj map[string]interface{}) labels.Set {
+	set := labels.Set{}
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	ls, _ := metadata["labels"].(map[string]interface{})
+	for k, v := range ls {
+		set[k], _ = v.(string)
+	}
+	return set
+}
+
+func mergePatch(dst, patch map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		if pm, ok := v.(map[string]interface{}); ok {
+			if dm, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergePatch(dm, pm)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func writeStatus(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Status",
+		"code":       code,
+		"message":    message,
+	})
+}
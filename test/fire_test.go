@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1beta1 "github.com/hongyuxuan/tekton-sdk-go/service/v1beta1"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+type SuiteTestFire struct {
+	suite.Suite
+	server    *fakeAPIServer
+	sink      *httptest.Server
+	name      string
+	namespace string
+	payload   []byte
+}
+
+func (s *SuiteTestFire) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.name = "testeventlistener"
+	s.namespace = "default"
+	s.payload = []byte(`{"ref": "refs/heads/main"}`)
+
+	// the sink reached directly at status.address.url
+	s.sink = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Empty(r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"eventListener":"testeventlistener","namespace":"default","eventListenerUID":"1234","eventID":"direct"}`))
+	}))
+	url, _ := apis.ParseURL(s.sink.URL)
+	el := tektonv1beta1.EventListener{ObjectMeta: metav1.ObjectMeta{Name: s.name}}
+	el.Status.Address = &duckv1beta1.Addressable{URL: url}
+	el.Status.Configuration.GeneratedResourceName = "el-" + s.name
+	s.server.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/default/eventlisteners", el)
+
+	// a cluster without CustomRuns
+	s.server.handle("/apis/tekton.dev/v1beta1/namespaces/default/customruns", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusNotFound, "the server could not find the requested resource")
+	})
+	// the sink reached through the API server service proxy, where two triggers create a PipelineRun
+	// and a TaskRun a bit later, one after the other
+	s.server.handle("/api/v1/namespaces/default/services/http:el-testeventlistener:http-listener/proxy/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if r.Header.Get("X-Hub-Signature-256") != "sha256="+hex.EncodeToString(mac.Sum(nil)) || r.Header.Get("X-GitHub-Event") != "push" {
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]string{"eventListener": s.name, "eventID": "rejected"})
+			return
+		}
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelineruns", tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "testpipelinerun-x7k2p", Labels: map[string]string{
+					"triggers.tekton.dev/triggers-eventid": "proxied",
+				}},
+			})
+			time.Sleep(1200 * time.Millisecond)
+			s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/taskruns", tektonv1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "testtaskrun-9fq4d", Labels: map[string]string{
+					"triggers.tekton.dev/triggers-eventid": "proxied",
+				}},
+			})
+		}()
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"eventListener": s.name, "namespace": s.namespace, "eventListenerUID": "1234", "eventID": "proxied"})
+	})
+}
+
+func (s *SuiteTestFire) TearDownSuite() {
+	s.sink.Close()
+	s.server.Close()
+}
+
+func (s *SuiteTestFire) Test1FireThroughProxy() {
+	res, err := s.server.client().EventListener(s.namespace).Fire(context.TODO(), s.name, s.payload, map[string]string{
+		"X-GitHub-Event": "push",
+	}, v1beta1.FireOptions{GitHubSecret: "secret", Wait: 5 * time.Second})
+	s.Require().NoError(err)
+	s.Equal(http.StatusAccepted, res.StatusCode)
+	s.Equal("proxied", res.EventID)
+	if s.Len(res.Resources, 2) {
+		s.Equal("PipelineRun", res.Resources[0].Kind)
+		s.Equal("testpipelinerun-x7k2p", res.Resources[0].Name)
+		s.Equal("TaskRun", res.Resources[1].Kind)
+	}
+	reqs := s.server.requestsMatching("POST", "/api/v1/namespaces/default/services/")
+	if s.Len(reqs, 1) {
+		s.JSONEq(string(s.payload), reqs[0].Body)
+	}
+}
+
+func (s *SuiteTestFire) Test2FireUnsigned() {
+	res, err := s.server.client().EventListener(s.namespace).Fire(context.TODO(), s.name, s.payload, nil)
+	s.Require().NoError(err)
+	s.Equal("rejected", res.EventID)
+	s.Empty(res.Resources)
+}
+
+func (s *SuiteTestFire) Test3FireToAddress() {
+	res, err := s.server.client().EventListener(s.namespace).Fire(context.TODO(), s.name, s.payload, nil, v1beta1.FireOptions{UseAddress: true})
+	s.Require().NoError(err)
+	s.Equal("direct", res.EventID)
+	s.Equal("1234", res.EventListenerUID)
+}
+
+func (s *SuiteTestFire) Test4FireListForbidden() {
+	server := newFakeAPIServer()
+	defer server.Close()
+	url, _ := apis.ParseURL(s.sink.URL)
+	el := tektonv1beta1.EventListener{ObjectMeta: metav1.ObjectMeta{Name: s.name}}
+	el.Status.Address = &duckv1beta1.Addressable{URL: url}
+	server.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/default/eventlisteners", el)
+	server.handle("/apis/tekton.dev/v1/namespaces/default/pipelineruns", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusForbidden, "pipelineruns.tekton.dev is forbidden")
+	})
+	res, err := server.client().EventListener(s.namespace).Fire(context.TODO(), s.name, s.payload, nil, v1beta1.FireOptions{
+		UseAddress: true,
+		Wait:       1500 * time.Millisecond,
+	})
+	s.ErrorContains(err, "pipelineruns.tekton.dev is forbidden")
+	s.Equal("direct", res.EventID)
+}
+
+func TestSuiteTestFire(t *testing.T) {
+	suite.Run(t, new(SuiteTestFire))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hongyuxuan/tekton-sdk-go/graph"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+type SuiteTestGraph struct {
+	suite.Suite
+	spec tektonv1.PipelineSpec
+}
+
+func (s *SuiteTestGraph) SetupSuite() {
+	ref := func(name string) *tektonv1.TaskRef { return &tektonv1.TaskRef{Name: name} }
+	s.spec = tektonv1.PipelineSpec{
+		Tasks: []tektonv1.PipelineTask{
+			{Name: "git-clone", TaskRef: ref("git-clone")},
+			{Name: "lint", TaskRef: ref("golangci-lint"), RunAfter: []string{"git-clone"}},
+			{Name: "build", TaskRef: ref("go1.19-build"), RunAfter: []string{"git-clone"}},
+			{Name: "docker-build", TaskRef: ref("kaniko"), Params: tektonv1.Params{
+				{Name: "IMAGE", Value: *tektonv1.NewStructuredValues("feishu:$(tasks.build.results.version)-$(tasks.git-clone.results.commit)")},
+			}},
+			{Name: "deploy", TaskRef: ref("k8s-deploy"), RunAfter: []string{"lint"}, When: tektonv1.WhenExpressions{
+				{Input: "$(tasks.docker-build.results.IMAGE_URL)", Operator: selection.NotIn, Values: []string{""}},
+			}},
+		},
+		Finally: []tektonv1.PipelineTask{
+			{Name: "notify", TaskRef: ref("send-to-webhook"), When: tektonv1.WhenExpressions{
+				{Input: "$(tasks.deploy.status)", Operator: selection.In, Values: []string{"Failed"}},
+			}},
+		},
+	}
+}
+
+func (s *SuiteTestGraph) Test1Layers() {
+	g := graph.Build(&s.spec)
+	s.Nil(g.Err())
+	s.Equal([][]string{{"git-clone"}, {"lint", "build"}, {"docker-build"}, {"deploy"}, {"notify"}}, g.Layers)
+	s.ElementsMatch([]string{"build", "git-clone"}, g.Parents("docker-build"))
+	s.ElementsMatch([]string{"lint", "docker-build"}, g.Parents("deploy"))
+	s.Contains(g.Edges, graph.Edge{From: "deploy", To: "notify", Kind: graph.EdgeWhen, Detail: "status"})
+	node, ok := g.Node("notify")
+	if s.True(ok) {
+		s.True(node.Finally)
+		s.Equal(4, node.Layer)
+	}
+}
+
+func (s *SuiteTestGraph) Test2CyclesAndDangling() {
+	spec := s.spec.DeepCopy()
+	spec.Tasks[0].RunAfter = []string{"deploy"}
+	spec.Tasks[1].RunAfter = append(spec.Tasks[1].RunAfter, "test")
+	spec.Results = []tektonv1.PipelineResult{{Name: "digest", Value: *tektonv1.NewStructuredValues("$(tasks.push.results.digest)")}}
+	g := graph.Build(spec)
+	s.Equal([][]string{{"git-clone", "lint", "deploy"}}, g.Cycles)
+	s.ElementsMatch([]graph.DanglingRef{
+		{Task: "lint", Ref: "test", Kind: graph.EdgeRunAfter},
+		{Ref: "push", Kind: graph.EdgeResult},
+	}, g.Dangling)
+	s.ErrorContains(g.Err(), "cycle git-clone -> lint -> deploy -> git-clone")
+	node, _ := g.Node("build")
+	s.Equal(-1, node.Layer)
+}
+
+func (s *SuiteTestGraph) Test3Export() {
+	g := graph.Build(&s.spec)
+	dot := g.DOT()
+	s.Contains(dot, `"git-clone" -> "lint";`)
+	s.Contains(dot, `"build" -> "docker-build" [style=dashed, label="result: version"];`)
+	s.Contains(dot, `"notify" [label="notify (send-to-webhook)", style=dashed];`)
+	mermaid := g.Mermaid()
+	s.Contains(mermaid, "flowchart LR\n")
+	s.Contains(mermaid, "t_git_clone --> t_lint\n")
+	s.Contains(mermaid, "t_deploy -. when: status .-> t_notify\n")
+}
+
+func (s *SuiteTestGraph) Test4PipelineGraph() {
+	server := newFakeAPIServer()
+	defer server.Close()
+	server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelines", tektonv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "testpipeline"},
+		Spec:       s.spec,
+	})
+	g, err := server.client().Pipeline("default").Graph(context.TODO(), "testpipeline")
+	s.Nil(err)
+	s.Len(g.Layers, 5)
+}
+
+func TestSuiteTestGraph(t *testing.T) {
+	suite.Run(t, new(SuiteTestGraph))
+}
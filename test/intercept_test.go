@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hongyuxuan/tekton-sdk-go/simulate"
+	"github.com/stretchr/testify/suite"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const interceptYaml = `apiVersion: triggers.tekton.dev/v1beta1
+kind: EventListener
+metadata:
+  name: testeventlistener
+  namespace: default
+spec:
+  triggers:
+  - name: push
+    interceptors:
+    - ref:
+        name: github
+      params:
+      - name: eventTypes
+        value: ["push"]
+    - name: filter-main
+      ref:
+        name: cel
+      params:
+      - name: filter
+        value: header.match('X-GitHub-Event', 'push') && body.ref.startsWith('refs/heads/')
+      - name: overlays
+        value:
+        - key: branch
+          expression: body.ref.split('/')[2]
+        - key: short_sha
+          expression: body.head_commit.id.truncate(7)
+        - key: authorized
+          expression: "'s3cr3t'.compareSecret('token', 'webhook')"
+    - ref:
+        name: cel
+      params:
+      - name: filter
+        value: extensions.branch == 'main'
+  - name: tagged
+    triggerRef: tagged
+`
+
+type SuiteTestIntercept struct {
+	suite.Suite
+	el    tektonv1beta1.EventListener
+	event simulate.Event
+	opts  simulate.InterceptOptions
+}
+
+func (s *SuiteTestIntercept) SetupTest() {
+	s.Require().NoError(yaml.Unmarshal([]byte(interceptYaml), &s.el))
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "push")
+	s.event = simulate.Event{
+		Body:   []byte(`{"ref": "refs/heads/main", "head_commit": {"id": "b2e5f3a9c0d1"}}`),
+		Header: header,
+	}
+	s.opts = simulate.InterceptOptions{Secrets: map[string]map[string]string{"webhook": {"token": "s3cr3t"}}}
+}
+
+func (s *SuiteTestIntercept) Test1Match() {
+	res, err := simulate.InterceptEventListener(context.TODO(), s.event, &s.el, "push", s.opts)
+	s.Require().NoError(err)
+	s.True(res.Match)
+	s.Empty(res.Skipped)
+	s.Equal("main", res.Extensions["branch"])
+	s.Equal("b2e5f3a", res.Extensions["short_sha"])
+	s.Equal(true, res.Extensions["authorized"])
+}
+
+func (s *SuiteTestIntercept) Test2NoMatch() {
+	s.event.Body = []byte(`{"ref": "refs/heads/develop", "head_commit": {"id": "b2e5f3a9c0d1"}}`)
+	res, err := simulate.InterceptEventListener(context.TODO(), s.event, &s.el, "push", s.opts)
+	s.Require().NoError(err)
+	s.False(res.Match)
+	s.Equal("cel", res.StoppedBy)
+	s.Contains(res.Message, "extensions.branch == 'main'")
+	s.Equal("develop", res.Extensions["branch"])
+
+	s.event.Header.Set("X-GitHub-Event", "pull_request")
+	res, err = simulate.InterceptEventListener(context.TODO(), s.event, &s.el, "push", s.opts)
+	s.Require().NoError(err)
+	s.False(res.Match)
+	s.Equal("github", res.StoppedBy)
+	s.Contains(res.Message, "event type pull_request is not allowed")
+}
+
+func (s *SuiteTestIntercept) Test3Errors() {
+	_, err := simulate.InterceptEventListener(context.TODO(), s.event, &s.el, "push")
+	s.ErrorContains(err, "interceptor filter-main")
+
+	_, err = simulate.InterceptEventListener(context.TODO(), s.event, &s.el, "tagged")
+	s.ErrorContains(err, "references Trigger tagged")
+
+	_, err = simulate.Intercept(context.TODO(), s.event, []*tektonv1beta1.TriggerInterceptor{{
+		Ref:    tektonv1beta1.InterceptorRef{Name: "cel"},
+		Params: []tektonv1beta1.InterceptorParams{{Name: "filter", Value: apiextensionsv1.JSON{Raw: []byte(`"body.ref =="`)}}},
+	}})
+	s.ErrorContains(err, "failed to parse expression")
+}
+
+func TestSuiteTestIntercept(t *testing.T) {
+	suite.Run(t, new(SuiteTestIntercept))
+}
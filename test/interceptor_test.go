@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	"github.com/hongyuxuan/tekton-sdk-go/service"
+	"github.com/stretchr/testify/suite"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"knative.dev/pkg/apis"
+)
+
+type SuiteTestInterceptor struct {
+	suite.Suite
+	server    *fakeAPIServer
+	namespace string
+}
+
+func (s *SuiteTestInterceptor) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+}
+
+func (s *SuiteTestInterceptor) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestInterceptor) Test1CreateClusterTriggerBinding() {
+	res, err := s.server.client().ClusterTriggerBinding().CreateObject(context.TODO(), &tektonv1beta1.ClusterTriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Labels: map[string]string{"app": "testclustertriggerbinding"}},
+		Spec: tektonv1beta1.TriggerBindingSpec{Params: []tektonv1beta1.Param{
+			{Name: "gitrevision", Value: "$(body.head_commit.id)"},
+		}},
+	})
+	s.Require().NoError(err)
+	s.Equal("ClusterTriggerBinding", res.Kind)
+	s.Empty(res.Namespace)
+	s.Len(s.server.requestsMatching("POST", "/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings"), 1)
+}
+
+func (s *SuiteTestInterceptor) Test2CreateInterceptors() {
+	url, _ := apis.ParseURL("http://custom-interceptor.default.svc:8443")
+	_, err := s.server.client().ClusterInterceptor().CreateObject(context.TODO(), &v1alpha1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom", Labels: map[string]string{"app": "testclusterinterceptor"}},
+		Spec:       v1alpha1.ClusterInterceptorSpec{ClientConfig: v1alpha1.ClientConfig{URL: url}},
+	})
+	s.Require().NoError(err)
+	port := int32(8443)
+	_, err = s.server.client().Interceptor(s.namespace).CreateObject(context.TODO(), &v1alpha1.Interceptor{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Labels: map[string]string{"app": "testinterceptor"}},
+		Spec: v1alpha1.InterceptorSpec{ClientConfig: v1alpha1.ClientConfig{Service: &v1alpha1.ServiceReference{
+			Name: "team-interceptor", Namespace: s.namespace, Port: &port,
+		}}},
+	})
+	s.Require().NoError(err)
+
+	interceptor, err := s.server.client().Interceptor(s.namespace).Get(context.TODO(), "team")
+	s.Require().NoError(err)
+	addr, err := interceptor.ResolveAddress()
+	s.Require().NoError(err)
+	s.Equal("http://team-interceptor.default.svc:8443", addr.String())
+}
+
+func (s *SuiteTestInterceptor) Test3ListClusterInterceptor() {
+	res, err := s.server.client().ClusterInterceptor().List(context.TODO(), metav1.ListOptions{LabelSelector: "app=testclusterinterceptor"})
+	s.Require().NoError(err)
+	if s.Len(res, 1) {
+		s.Equal("custom", res[0].Name)
+	}
+}
+
+func (s *SuiteTestInterceptor) Test4WatchClusterTriggerBinding() {
+	events, err := s.server.client().ClusterTriggerBinding().Watch(context.TODO(), metav1.ListOptions{LabelSelector: "app=testclustertriggerbinding"})
+	s.Require().NoError(err)
+	var names []string
+	for e := range events {
+		s.Equal(watch.Added, e.Type)
+		names = append(names, e.Object.Name)
+	}
+	s.Equal([]string{"github"}, names)
+	reqs := s.server.requestsMatching("GET", "/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings")
+	s.Contains(reqs[len(reqs)-1].Query, "watch=true")
+}
+
+func (s *SuiteTestInterceptor) Test5WatchError() {
+	events, err := s.server.client().Interceptor(s.namespace).Watch(context.TODO(), metav1.ListOptions{})
+	s.Require().NoError(err)
+	var last service.WatchEvent[v1alpha1.Interceptor]
+	for e := range events {
+		last = e
+	}
+	s.Equal(watch.Error, last.Type)
+	if s.IsType(&errorx.TektonError{}, last.Err) {
+		s.Equal(int64(http.StatusGone), last.Err.(*errorx.TektonError).Code)
+	}
+}
+
+func (s *SuiteTestInterceptor) Test6WatchOutlivesRequestTimeout() {
+	s.server.handle("/apis/triggers.tekton.dev/v1alpha1/namespaces/slow/interceptors", func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		for _, name := range []string{"first", "second"} {
+			enc.Encode(map[string]interface{}{"type": "ADDED", "object": map[string]interface{}{"metadata": map[string]interface{}{"name": name}}})
+			w.(http.Flusher).Flush()
+			time.Sleep(300 * time.Millisecond)
+		}
+	})
+	client := s.server.client()
+	client.Config.Httpclient.SetTimeout(100 * time.Millisecond)
+	events, err := client.Interceptor("slow").Watch(context.TODO(), metav1.ListOptions{})
+	s.Require().NoError(err)
+	var names []string
+	for e := range events {
+		s.Require().NoError(e.Err)
+		names = append(names, e.Object.Name)
+	}
+	s.Equal([]string{"first", "second"}, names)
+}
+
+func (s *SuiteTestInterceptor) Test7WatchBrokenStream() {
+	s.server.handle("/apis/triggers.tekton.dev/v1alpha1/namespaces/broken/interceptors", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"type":"ADDED","object":{"metadata":{"name":"first"}}}` + "\n" + `{"type":"ADDED","obj`))
+	})
+	events, err := s.server.client().Interceptor("broken").Watch(context.TODO(), metav1.ListOptions{})
+	s.Require().NoError(err)
+	var got []service.WatchEvent[v1alpha1.Interceptor]
+	for e := range events {
+		got = append(got, e)
+	}
+	s.Require().Len(got, 2)
+	s.Equal("first", got[0].Object.Name)
+	s.Equal(watch.Error, got[1].Type)
+	s.ErrorContains(got[1].Err, "watch stream broken")
+}
+
+func (s *SuiteTestInterceptor) Test8DeleteInterceptors() {
+	s.Nil(s.server.client().Interceptor(s.namespace).Delete(context.TODO(), "team"))
+	s.Nil(s.server.client().ClusterInterceptor().DeleteCollection(context.TODO(), metav1.ListOptions{LabelSelector: "app=testclusterinterceptor"}))
+	s.Nil(s.server.client().ClusterTriggerBinding().Delete(context.TODO(), "github"))
+	s.Empty(s.server.names("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors"))
+	s.Empty(s.server.names("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings"))
+}
+
+func TestSuiteTestInterceptor(t *testing.T) {
+	suite.Run(t, new(SuiteTestInterceptor))
+}
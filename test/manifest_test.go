@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hongyuxuan/tekton-sdk-go/manifest"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type SuiteTestManifest struct {
+	suite.Suite
+	server    *fakeAPIServer
+	name      string
+	namespace string
+}
+
+func (s *SuiteTestManifest) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.name = "testpipelinerun"
+	s.namespace = "default"
+	controller := true
+	pr := tektonv1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       s.name,
+			Finalizers: []string{"chains.tekton.dev/pipelinerun"},
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "triggers.tekton.dev/v1beta1", Kind: "EventListener", Name: "github", UID: "1234", Controller: &controller},
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate}},
+		},
+		Spec: tektonv1.PipelineRunSpec{PipelineRef: &tektonv1.PipelineRef{Name: "testpipeline"}},
+	}
+	pr.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: "True", Reason: "Succeeded"}}
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelineruns", pr)
+}
+
+func (s *SuiteTestManifest) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestManifest) Test1GetYaml() {
+	out, err := s.server.client().PipelineRun(s.namespace).GetYaml(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.Contains(out, "ownerReferences:")
+	s.Contains(out, "finalizers:")
+	s.Contains(out, "uid: ")
+	s.NotContains(out, "managedFields")
+	s.NotContains(out, "last-applied-configuration")
+	s.NotContains(out, "annotations:")
+	s.NotContains(out, "status:")
+	s.Regexp(`^apiVersion: tekton.dev/v1\nkind: PipelineRun\nmetadata:\n`, out)
+
+	again, err := s.server.client().PipelineRun(s.namespace).GetYaml(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.Equal(out, again)
+}
+
+func (s *SuiteTestManifest) Test2Profiles() {
+	pr := s.server.client().PipelineRun(s.namespace)
+	out, err := pr.GetManifest(context.TODO(), s.name, manifest.Options{Format: manifest.FormatJSON, Profile: &manifest.ProfileAudit})
+	s.Require().NoError(err)
+	var obj map[string]interface{}
+	s.Require().NoError(json.Unmarshal([]byte(out), &obj))
+	s.NotNil(obj["status"])
+	metadata := obj["metadata"].(map[string]interface{})
+	s.NotNil(metadata["uid"])
+	s.NotNil(metadata["resourceVersion"])
+	s.Nil(metadata["managedFields"])
+
+	out, err = pr.GetManifest(context.TODO(), s.name, manifest.Options{Profile: &manifest.ProfileApply})
+	s.Require().NoError(err)
+	s.NotContains(out, "ownerReferences:")
+	s.NotContains(out, "uid:")
+	s.NotContains(out, "resourceVersion:")
+	s.Contains(out, "finalizers:")
+	s.Contains(out, "pipelineRef:")
+
+	out, err = pr.GetManifest(context.TODO(), s.name, manifest.Options{Profile: &manifest.Profile{Metadata: []string{"finalizers"}}})
+	s.Require().NoError(err)
+	s.NotContains(out, "finalizers:")
+	s.Contains(out, "managedFields:")
+	s.Contains(out, "last-applied-configuration")
+
+	_, err = pr.GetManifest(context.TODO(), s.name, manifest.Options{Format: "toml"})
+	s.ErrorContains(err, "unsupported format toml")
+}
+
+func TestSuiteTestManifest(t *testing.T) {
+	suite.Run(t, new(SuiteTestManifest))
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/hongyuxuan/tekton-sdk-go/bundle"
+	v1 "github.com/hongyuxuan/tekton-sdk-go/service/v1"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const expandGitTask = `apiVersion: tekton.dev/v1
+kind: Task
+metadata:
+  name: lint
+spec:
+  steps:
+  - name: lint
+    ref:
+      name: golangci-lint
+`
+
+const expandHubStepAction = `apiVersion: tekton.dev/v1beta1
+kind: StepAction
+metadata:
+  name: upload
+spec:
+  image: curlimages/curl
+  args: ["-T", "$(params.file)", "$(params.url)"]
+  params:
+  - name: file
+  - name: url
+    default: https://artifacts.example.com
+`
+
+// expandBundleTask is a v1beta1 Task as most catalogs still publish them, its step resources are
+// computeResources in v1
+const expandBundleTask = `apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: scan
+spec:
+  steps:
+  - name: scan
+    image: aquasec/trivy
+    resources:
+      limits:
+        memory: 1Gi
+  - name: upload
+    ref:
+      resolver: hub
+      params:
+      - name: name
+        value: upload
+      - name: kind
+        value: stepaction
+    params:
+    - name: file
+      value: report.json
+`
+
+const expandAlphaStepAction = `apiVersion: tekton.dev/v1alpha1
+kind: StepAction
+metadata:
+  name: upload
+spec:
+  image: curlimages/curl
+  args: ["-T", "$(params.file)"]
+  params:
+  - name: file
+`
+
+type SuiteTestPipelineExpand struct {
+	suite.Suite
+	server    *fakeAPIServer
+	registry  *httptest.Server
+	namespace string
+	opts      v1.ExpandOptions
+}
+
+func (s *SuiteTestPipelineExpand) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+	param := func(name, value string) tektonv1.Param {
+		return tektonv1.Param{Name: name, Value: *tektonv1.NewStructuredValues(value)}
+	}
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelines", tektonv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: s.namespace},
+		Spec: tektonv1.PipelineSpec{
+			Tasks: []tektonv1.PipelineTask{
+				{Name: "clone", TaskRef: &tektonv1.TaskRef{Name: "git-clone"}},
+				{Name: "lint", RunAfter: []string{"clone"}, TaskRef: &tektonv1.TaskRef{ResolverRef: tektonv1.ResolverRef{
+					Resolver: "git",
+					Params:   tektonv1.Params{param("url", "https://github.com/example/catalog.git"), param("pathInRepo", "task/lint/lint.yaml")},
+				}}},
+				{Name: "scan", RunAfter: []string{"clone"}, TaskRef: &tektonv1.TaskRef{ResolverRef: tektonv1.ResolverRef{
+					Resolver: "bundles",
+					Params:   tektonv1.Params{param("bundle", "registry.example.com/tasks/scan:1.0"), param("name", "scan"), param("kind", "task")},
+				}}},
+				{Name: "approve", TaskRef: &tektonv1.TaskRef{APIVersion: "openshift-pipelines.org/v1alpha1", Kind: "ApprovalTask"}},
+			},
+			Finally: []tektonv1.PipelineTask{{Name: "publish", TaskSpec: &tektonv1.EmbeddedTask{TaskSpec: tektonv1.TaskSpec{
+				Steps: []tektonv1.Step{{Name: "upload", Ref: &tektonv1.Ref{ResolverRef: tektonv1.ResolverRef{
+					Resolver: "hub",
+					Params:   tektonv1.Params{param("name", "upload"), param("kind", "stepaction")},
+				}}, Params: tektonv1.Params{param("file", "dist/app.tar.gz")}}},
+			}}}},
+		},
+	})
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/tasks", tektonv1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-clone", Namespace: s.namespace},
+		Spec: tektonv1.TaskSpec{Steps: []tektonv1.Step{{
+			Name:   "clone",
+			Ref:    &tektonv1.Ref{Name: "git-clone"},
+			Params: tektonv1.Params{param("url", "https://github.com/example/app.git")},
+		}}},
+	})
+	s.server.addJSON("/apis/tekton.dev/v1beta1/namespaces/default/stepactions", pipelinev1beta1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-clone", Namespace: s.namespace},
+		Spec: pipelinev1beta1.StepActionSpec{
+			Image:  "alpine/git",
+			Script: "git clone --depth=$(params.depth) $(params.url) .",
+			Params: tektonv1.ParamSpecs{{Name: "url"}, {Name: "depth", Default: tektonv1.NewStructuredValues("1")}},
+		},
+	})
+	s.server.addJSON("/apis/tekton.dev/v1beta1/namespaces/default/stepactions", pipelinev1beta1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "golangci-lint", Namespace: s.namespace},
+		Spec:       pipelinev1beta1.StepActionSpec{Image: "golangci/golangci-lint", Command: []string{"golangci-lint", "run"}},
+	})
+
+	s.registry = httptest.NewServer(registry.New())
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelines", tektonv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "scan", Namespace: s.namespace},
+		Spec: tektonv1.PipelineSpec{Tasks: []tektonv1.PipelineTask{
+			{Name: "scan", TaskRef: &tektonv1.TaskRef{ResolverRef: tektonv1.ResolverRef{
+				Resolver: "bundles",
+				Params:   tektonv1.Params{param("bundle", strings.TrimPrefix(s.registry.URL, "http://")+"/tasks/scan:1.0"), param("name", "scan")},
+			}}},
+		}},
+	})
+
+	dir := s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, "task", "lint"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "task", "lint", "lint.yaml"), []byte(expandGitTask), 0o644))
+	s.opts = v1.ExpandOptions{Fetchers: map[string]v1.Fetcher{
+		"git": v1.GitDirFetcher(dir),
+		"hub": v1.FetcherFunc(func(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error) {
+			return []byte(expandHubStepAction), &tektonv1.RefSource{URI: "https://artifacthub.io/tekton-stepaction/upload"}, nil
+		}),
+	}}
+}
+
+func (s *SuiteTestPipelineExpand) TearDownSuite() {
+	s.server.Close()
+	s.registry.Close()
+}
+
+func (s *SuiteTestPipelineExpand) Test1Expand() {
+	res, err := s.server.client().Pipeline(s.namespace).Expand(context.TODO(), "build", s.opts)
+	s.Require().NoError(err)
+
+	clone := res.Spec.Tasks[0]
+	s.Nil(clone.TaskRef)
+	s.Require().NotNil(clone.TaskSpec)
+	step := clone.TaskSpec.Steps[0]
+	s.Nil(step.Ref)
+	s.Equal("clone", step.Name)
+	s.Equal("alpine/git", step.Image)
+	s.Equal("git clone --depth=1 https://github.com/example/app.git .", step.Script)
+
+	lint := res.Spec.Tasks[1]
+	s.Require().NotNil(lint.TaskSpec)
+	s.Equal([]string{"golangci-lint", "run"}, lint.TaskSpec.Steps[0].Command)
+	s.Equal([]string{"clone"}, lint.RunAfter)
+
+	s.NotNil(res.Spec.Tasks[2].TaskRef)
+	s.NotNil(res.Spec.Tasks[3].TaskRef)
+	s.Equal([]string{"spec.tasks[2].taskRef", "spec.tasks[3].taskRef"}, res.Unresolved)
+
+	upload := res.Spec.Finally[0].TaskSpec.Steps[0]
+	s.Equal("curlimages/curl", upload.Image)
+	s.Equal([]string{"-T", "dist/app.tar.gz", "https://artifacts.example.com"}, upload.Args)
+
+	var paths []string
+	for _, p := range res.Provenance {
+		paths = append(paths, p.Kind+"/"+p.Name+"@"+p.Path)
+	}
+	s.Equal([]string{
+		"Task/git-clone@spec.tasks[0].taskSpec",
+		"StepAction/git-clone@spec.tasks[0].taskSpec.steps[0]",
+		"Task/lint@spec.tasks[1].taskSpec",
+		"StepAction/golangci-lint@spec.tasks[1].taskSpec.steps[0]",
+		"StepAction/upload@spec.finally[0].taskSpec.steps[0]",
+	}, paths)
+	s.Equal("git", res.Provenance[2].Resolver)
+	s.Equal("task/lint/lint.yaml", res.Provenance[2].Source.EntryPoint)
+	s.Equal("https://github.com/example/catalog.git", res.Provenance[2].Source.URI)
+
+	// the Pipeline itself is left as returned by the server
+	s.NotNil(res.Pipeline.Spec.Tasks[0].TaskRef)
+}
+
+func (s *SuiteTestPipelineExpand) Test2Errors() {
+	_, err := s.server.client().Pipeline(s.namespace).Expand(context.TODO(), "build", v1.ExpandOptions{Fetchers: map[string]v1.Fetcher{
+		"git": v1.GitDirFetcher(s.T().TempDir()),
+	}})
+	s.ErrorContains(err, "spec.tasks[1].taskRef: git resolver: cannot read task/lint/lint.yaml")
+
+	_, err = s.server.client().Pipeline(s.namespace).Expand(context.TODO(), "build", v1.ExpandOptions{Fetchers: map[string]v1.Fetcher{
+		"git": v1.FetcherFunc(func(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error) {
+			return []byte(expandHubStepAction), nil, nil
+		}),
+	}})
+	s.ErrorContains(err, "git resolver returned a StepAction, not a Task")
+
+	fetcher := v1.GitDirFetcher(s.T().TempDir())
+	for _, path := range []string{"../secret.yaml", "task/../../secret.yaml", "/etc/passwd"} {
+		_, _, err = fetcher.Fetch(context.TODO(), "git", tektonv1.Params{{Name: "pathInRepo", Value: *tektonv1.NewStructuredValues(path)}})
+		s.ErrorContains(err, "is not a path inside the repository", path)
+	}
+
+	_, err = s.server.client().Pipeline(s.namespace).Expand(context.TODO(), "build", v1.ExpandOptions{Fetchers: map[string]v1.Fetcher{
+		"git": v1.FetcherFunc(func(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error) {
+			return []byte(strings.Replace(expandGitTask, "tekton.dev/v1", "tekton.dev/v2", 1)), nil, nil
+		}),
+	}})
+	s.ErrorContains(err, "cannot decode tekton.dev/v2 Task: unsupported apiVersion tekton.dev/v2")
+}
+
+func (s *SuiteTestPipelineExpand) Test3ExpandBundle() {
+	img, err := bundle.BuildYaml(expandBundleTask)
+	s.Require().NoError(err)
+	ref := strings.TrimPrefix(s.registry.URL, "http://") + "/tasks/scan:1.0"
+	digest, err := bundle.Push(context.TODO(), ref, img, bundle.Options{Keychain: authn.NewMultiKeychain(), Insecure: true})
+	s.Require().NoError(err)
+
+	res, err := s.server.client().Pipeline(s.namespace).Expand(context.TODO(), "scan", v1.ExpandOptions{Fetchers: map[string]v1.Fetcher{
+		"bundles": v1.BundleFetcher(bundle.Options{Keychain: authn.NewMultiKeychain(), Insecure: true}),
+		"hub": v1.FetcherFunc(func(ctx context.Context, resolver string, params tektonv1.Params) ([]byte, *tektonv1.RefSource, error) {
+			return []byte(expandAlphaStepAction), nil, nil
+		}),
+	}})
+	s.Require().NoError(err)
+	s.Empty(res.Unresolved)
+	steps := res.Spec.Tasks[0].TaskSpec.Steps
+	s.Require().Len(steps, 2)
+	s.Equal("1Gi", steps[0].ComputeResources.Limits.Memory().String())
+	s.Equal("curlimages/curl", steps[1].Image)
+	s.Equal([]string{"-T", "report.json"}, steps[1].Args)
+
+	source := res.Provenance[0].Source
+	s.Require().NotNil(source)
+	s.Equal(strings.TrimSuffix(ref, ":1.0"), source.URI)
+	s.Equal("sha256:"+source.Digest["sha256"], digest)
+	s.Equal("scan", source.EntryPoint)
+
+	_, _, err = v1.BundleFetcher(bundle.Options{Insecure: true}).Fetch(context.TODO(), "bundles", tektonv1.Params{
+		{Name: "bundle", Value: *tektonv1.NewStructuredValues(ref)},
+		{Name: "name", Value: *tektonv1.NewStructuredValues("missing")},
+	})
+	s.ErrorContains(err, "bundle has no task missing")
+}
+
+func TestSuiteTestPipelineExpand(t *testing.T) {
+	suite.Run(t, new(SuiteTestPipelineExpand))
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	v1 "github.com/hongyuxuan/tekton-sdk-go/service/v1"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type SuiteTestPrune struct {
+	suite.Suite
+	server    *fakeAPIServer
+	namespace string
+	path      string
+}
+
+func (s *SuiteTestPrune) SetupTest() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+	s.path = "/apis/tekton.dev/v1/namespaces/default/pipelineruns"
+	now := time.Now()
+	add := func(name, pipeline string, age time.Duration, status corev1.ConditionStatus, reason string) {
+		pr := tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			Labels:            map[string]string{"tekton.dev/pipeline": pipeline, "app": "testprune"},
+		}}
+		pr.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status, Reason: reason}}
+		s.server.addJSON(s.path, pr)
+	}
+	for i := 1; i <= 4; i++ {
+		add(fmt.Sprintf("build-%d", i), "build", time.Duration(5-i)*24*time.Hour, corev1.ConditionTrue, "Succeeded")
+	}
+	add("deploy-1", "deploy", 48*time.Hour, corev1.ConditionFalse, "Cancelled")
+	add("deploy-2", "deploy", time.Hour, corev1.ConditionUnknown, "Running")
+}
+
+func (s *SuiteTestPrune) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *SuiteTestPrune) Test1DryRun() {
+	res, err := s.server.client().PipelineRun(s.namespace).Prune(context.TODO(), v1.PruneOptions{
+		LabelSelector: "app=testprune",
+		KeepLast:      1,
+		DryRun:        true,
+	})
+	s.Require().NoError(err)
+	s.True(res.DryRun)
+	s.Equal([]string{"build-1", "build-2", "deploy-1", "build-3"}, res.Deleted)
+	s.Equal(2, res.Kept)
+	s.Len(s.server.names(s.path), 6)
+}
+
+func (s *SuiteTestPrune) Test2MaxAgeAndStatus() {
+	res, err := s.server.client().PipelineRun(s.namespace).Prune(context.TODO(), v1.PruneOptions{
+		MaxAge:            36 * time.Hour,
+		Statuses:          []string{v1.PruneStatusSucceeded},
+		PropagationPolicy: metav1.DeletePropagationForeground,
+		Concurrency:       2,
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{"build-1", "build-2", "build-3"}, res.Deleted)
+	s.Empty(res.Errors)
+	s.Equal([]string{"build-4", "deploy-1", "deploy-2"}, s.server.names(s.path))
+	for _, r := range s.server.requestsMatching("DELETE", s.path) {
+		s.Contains(r.Body, `"propagationPolicy":"Foreground"`)
+	}
+}
+
+func (s *SuiteTestPrune) Test3CancelledReason() {
+	res, err := s.server.client().PipelineRun(s.namespace).Prune(context.TODO(), v1.PruneOptions{
+		Statuses: []string{"Cancelled", v1.PruneStatusRunning},
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{"deploy-1", "deploy-2"}, res.Deleted)
+}
+
+func (s *SuiteTestPrune) Test4Pages() {
+	now := time.Now()
+	// more runs than a page of List, named so that the oldest ones come last by name
+	for i := 0; i < 600; i++ {
+		pr := tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+			Name:              fmt.Sprintf("nightly-%03d", i),
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Duration(i) * time.Minute)),
+			Labels:            map[string]string{"tekton.dev/pipeline": "nightly", "app": "nightly"},
+		}}
+		pr.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"}}
+		s.server.addJSON(s.path, pr)
+	}
+	res, err := s.server.client().PipelineRun(s.namespace).Prune(context.TODO(), v1.PruneOptions{
+		LabelSelector: "app=nightly",
+		KeepLast:      10,
+		DryRun:        true,
+	})
+	s.Require().NoError(err)
+	s.Len(res.Deleted, 590)
+	s.Equal(10, res.Kept)
+	s.Equal("nightly-599", res.Deleted[0])
+	s.NotContains(res.Deleted, "nightly-009")
+	s.Len(s.server.requestsMatching("GET", s.path), 2)
+}
+
+func (s *SuiteTestPrune) Test5Cancelled() {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	// the first delete cancels the prune
+	s.server.handle(s.path+"/", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		writeStatus(w, http.StatusOK, "")
+	})
+	res, err := s.server.client().PipelineRun(s.namespace).Prune(ctx, v1.PruneOptions{
+		Statuses:    []string{v1.PruneStatusSucceeded},
+		Concurrency: 1,
+	})
+	s.Require().NoError(err)
+	s.Len(s.server.requestsMatching("DELETE", s.path), 1)
+	s.Equal(4, len(res.Deleted)+len(res.Errors))
+	s.ErrorIs(res.Errors["build-4"], context.Canceled)
+}
+
+func TestSuiteTestPrune(t *testing.T) {
+	suite.Run(t, new(SuiteTestPrune))
+}
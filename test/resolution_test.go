@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const resolvedTask = `apiVersion: tekton.dev/v1
+kind: Task
+metadata:
+  name: git-clone
+spec:
+  steps:
+  - name: clone
+    image: alpine/git
+`
+
+type SuiteTestResolution struct {
+	suite.Suite
+	server    *fakeAPIServer
+	namespace string
+}
+
+func (s *SuiteTestResolution) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelineruns", tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-run", Namespace: s.namespace, UID: "build-run-uid"},
+		Spec: tektonv1.PipelineRunSpec{PipelineRef: &tektonv1.PipelineRef{ResolverRef: tektonv1.ResolverRef{
+			Resolver: "git",
+			Params:   tektonv1.Params{{Name: "pathInRepo", Value: *tektonv1.NewStructuredValues("pipeline/build.yaml")}},
+		}}},
+		Status: tektonv1.PipelineRunStatus{PipelineRunStatusFields: tektonv1.PipelineRunStatusFields{
+			ChildReferences: []tektonv1.ChildStatusReference{{
+				TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, Name: "build-run-clone", PipelineTaskName: "clone",
+			}},
+		}},
+	})
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/taskruns", tektonv1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "build-run-clone",
+			UID:             "build-run-clone-uid",
+			Labels:          map[string]string{"tekton.dev/pipelineRun": "build-run"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Name: "build-run", UID: "build-run-uid"}},
+		},
+	})
+	created := time.Now()
+	rr := func(name, resolver, ownerKind, owner string, cond apis.Condition, data string) resolutionv1beta1.ResolutionRequest {
+		created = created.Add(time.Second)
+		return resolutionv1beta1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         s.namespace,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels:            map[string]string{"resolution.tekton.dev/type": resolver},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "tekton.dev/v1", Kind: ownerKind, Name: owner, UID: k8stypes.UID(owner + "-uid"),
+				}},
+			},
+			Spec: resolutionv1beta1.ResolutionRequestSpec{Params: []tektonv1.Param{
+				{Name: "url", Value: *tektonv1.NewStructuredValues("https://github.com/example/catalog.git")},
+			}},
+			Status: resolutionv1beta1.ResolutionRequestStatus{
+				Status:                        duckv1.Status{Conditions: duckv1.Conditions{cond}},
+				ResolutionRequestStatusFields: resolutionv1beta1.ResolutionRequestStatusFields{Data: data},
+			},
+		}
+	}
+	for _, r := range []resolutionv1beta1.ResolutionRequest{
+		rr("git-pipeline", "git", "PipelineRun", "build-run",
+			apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}, base64.StdEncoding.EncodeToString([]byte(resolvedTask))),
+		rr("bundles-clone", "bundles", "TaskRun", "build-run-clone",
+			apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "ResolutionFailed", Message: "error pulling bundle: unauthorized"}, ""),
+		rr("hub-other", "hub", "PipelineRun", "other-run",
+			apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}, ""),
+	} {
+		s.server.addJSON("/apis/resolution.tekton.dev/v1beta1/namespaces/default/resolutionrequests", r)
+	}
+	// an earlier run with the same name, and more requests than a page of List before git-pipeline
+	stale := rr("git-pipeline-stale", "git", "PipelineRun", "build-run", apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}, "")
+	stale.OwnerReferences[0].UID = "earlier-build-run-uid"
+	s.server.addJSON("/apis/resolution.tekton.dev/v1beta1/namespaces/default/resolutionrequests", stale)
+	for i := 0; i < 600; i++ {
+		s.server.addJSON("/apis/resolution.tekton.dev/v1beta1/namespaces/default/resolutionrequests",
+			rr(fmt.Sprintf("cluster-%03d", i), "cluster", "PipelineRun", "other-run", apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}, ""))
+	}
+}
+
+func (s *SuiteTestResolution) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestResolution) Test1ForPipelineRun() {
+	res, err := s.server.client().ResolutionRequest(s.namespace).ForPipelineRun(context.TODO(), "build-run")
+	s.Require().NoError(err)
+	s.Require().Len(res, 2)
+
+	s.Equal("git-pipeline", res[0].Name)
+	s.Equal("git", res[0].Resolver)
+	s.Equal("PipelineRun/build-run", res[0].Owner)
+	s.True(res[0].Succeeded)
+	s.Equal(resolvedTask, res[0].Data)
+	s.Equal("https://github.com/example/catalog.git", res[0].Params[0].Value.StringVal)
+
+	s.Equal("bundles", res[1].Resolver)
+	s.Equal("TaskRun/build-run-clone", res[1].Owner)
+	s.True(res[1].Done)
+	s.False(res[1].Succeeded)
+	s.Equal("error pulling bundle: unauthorized", res[1].Message)
+}
+
+func (s *SuiteTestResolution) Test2List() {
+	res, err := s.server.client().ResolutionRequest(s.namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "resolution.tekton.dev/type=hub"})
+	s.Require().NoError(err)
+	if s.Len(res, 1) {
+		s.Equal("hub-other", res[0].Name)
+	}
+}
+
+func TestSuiteTestResolution(t *testing.T) {
+	suite.Run(t, new(SuiteTestResolution))
+}
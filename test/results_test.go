@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type SuiteTestResults struct {
+	suite.Suite
+	server    *fakeAPIServer
+	name      string
+	namespace string
+}
+
+func (s *SuiteTestResults) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.name = "testpipelinerun"
+	s.namespace = "default"
+
+	pr := tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: s.name}}
+	pr.Status.Results = []tektonv1.PipelineRunResult{
+		{Name: "commit", Value: *tektonv1.NewStructuredValues("b2e5f3a")},
+		{Name: "image", Value: *tektonv1.NewObject(map[string]string{"url": "repo.example.com/feishu", "digest": "sha256:abc"})},
+	}
+	pr.Status.ChildReferences = []tektonv1.ChildStatusReference{
+		{TypeMeta: runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"}, Name: s.name + "-build", PipelineTaskName: "build"},
+		// pruned after the run completed
+		{TypeMeta: runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"}, Name: s.name + "-test", PipelineTaskName: "test"},
+	}
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelineruns", pr)
+
+	tr := tektonv1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: s.name + "-build"}}
+	tr.Status.Results = []tektonv1.TaskRunResult{
+		{Name: "version", Type: tektonv1.ResultsTypeString, Value: *tektonv1.NewStructuredValues("1.0.0")},
+		{Name: "packages", Type: tektonv1.ResultsTypeArray, Value: *tektonv1.NewStructuredValues("api", "worker")},
+		{Name: "manifest", Type: tektonv1.ResultsTypeString, Value: *tektonv1.NewStructuredValues(`{"replicas": 3}`)},
+	}
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/taskruns", tr)
+}
+
+func (s *SuiteTestResults) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestResults) Test1PipelineRunResults() {
+	res, err := s.server.client().PipelineRun(s.namespace).Results(context.TODO(), s.name)
+	s.Require().NoError(err)
+
+	commit, ok := res.Results.Get("commit")
+	if s.True(ok) {
+		s.Equal(tektonv1.ResultsTypeString, commit.Type)
+		s.Equal("b2e5f3a", commit.String())
+	}
+	image, ok := res.Results.Get("image")
+	if s.True(ok) {
+		s.Equal(tektonv1.ResultsTypeObject, image.Type)
+		var v struct {
+			URL    string `json:"url"`
+			Digest string `json:"digest"`
+		}
+		s.Nil(image.Decode(&v))
+		s.Equal("sha256:abc", v.Digest)
+	}
+
+	build, ok := res.Task("build")
+	if s.True(ok) {
+		packages, _ := build.Get("packages")
+		s.Equal(tektonv1.ResultsTypeArray, packages.Type)
+		s.Equal([]string{"api", "worker"}, packages.Array())
+	}
+	_, ok = res.Task("test")
+	s.False(ok)
+	s.Equal([]string{s.name + "-test"}, res.Missing)
+}
+
+func (s *SuiteTestResults) Test2TaskRunResults() {
+	res, err := s.server.client().TaskRun(s.namespace).Results(context.TODO(), s.name+"-build")
+	s.Require().NoError(err)
+	manifest, ok := res.Get("manifest")
+	if s.True(ok) {
+		var v struct {
+			Replicas int `json:"replicas"`
+		}
+		s.Nil(manifest.Decode(&v))
+		s.Equal(3, v.Replicas)
+	}
+	version, _ := res.Get("version")
+	var v struct{}
+	s.ErrorContains(version.Decode(&v), "cannot decode string result version")
+}
+
+func TestSuiteTestResults(t *testing.T) {
+	suite.Run(t, new(SuiteTestResults))
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hongyuxuan/tekton-sdk-go/simulate"
+	"github.com/stretchr/testify/suite"
+)
+
+const simulateYaml = `apiVersion: triggers.tekton.dev/v1beta1
+kind: TriggerBinding
+metadata:
+  name: testtriggerbinding
+spec:
+  params:
+  - name: gitrevision
+    value: $(body.head_commit.id)
+  - name: gitrepositoryurl
+    value: $(body.repository.clone_url)
+  - name: event
+    value: $(header.X-Github-Event)
+  - name: application
+    value: $(extensions.app_name)
+---
+apiVersion: triggers.tekton.dev/v1beta1
+kind: TriggerTemplate
+metadata:
+  name: testtriggertemplate
+spec:
+  params:
+  - name: gitrevision
+  - name: gitrepositoryurl
+  - name: event
+  - name: application
+    default: unknown
+  - name: namespace
+    default: default
+  resourcetemplates:
+  - apiVersion: tekton.dev/v1
+    kind: PipelineRun
+    metadata:
+      generateName: $(tt.params.application)-
+      namespace: $(tt.params.namespace)
+      labels:
+        event: $(tt.params.event)
+    spec:
+      pipelineRef:
+        name: testpipeline
+      params:
+      - name: revision
+        value: $(tt.params.gitrevision)
+      - name: url
+        value: $(tt.params.gitrepositoryurl)
+      - name: run
+        value: $(uid)
+`
+
+type SuiteTestSimulate struct {
+	suite.Suite
+	event simulate.Event
+}
+
+func (s *SuiteTestSimulate) SetupTest() {
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "push")
+	s.event = simulate.Event{
+		Body:       []byte(`{"head_commit": {"id": "b2e5f3a"}, "repository": {"clone_url": "https://github.com/hongyuxuan/feishu.git"}}`),
+		Header:     header,
+		Extensions: map[string]interface{}{"app_name": "feishu"},
+		EventID:    "testevent",
+	}
+}
+
+func (s *SuiteTestSimulate) Test1RenderYaml() {
+	res, err := simulate.RenderYaml(s.event, simulateYaml)
+	s.Require().NoError(err)
+	s.Equal("testevent", res.EventID)
+	if s.Len(res.Resources, 1) {
+		pr := res.Resources[0]
+		s.Equal("PipelineRun", pr.GetKind())
+		s.Equal("feishu-", pr.GetGenerateName())
+		s.Equal("default", pr.GetNamespace())
+		s.Equal("push", pr.GetLabels()["event"])
+		params := pr.Object["spec"].(map[string]interface{})["params"].([]interface{})
+		s.Equal("b2e5f3a", params[0].(map[string]interface{})["value"])
+		s.Equal("https://github.com/hongyuxuan/feishu.git", params[1].(map[string]interface{})["value"])
+		s.NotEqual("$(uid)", params[2].(map[string]interface{})["value"])
+	}
+}
+
+func (s *SuiteTestSimulate) Test2DefaultsAndErrors() {
+	s.event.Extensions = nil
+	_, err := simulate.RenderYaml(s.event, simulateYaml)
+	s.Require().NoError(err, "application falls back to its default")
+
+	s.event.Body = []byte(`{"head_commit": {}}`)
+	_, err = simulate.RenderYaml(s.event, simulateYaml)
+	s.ErrorContains(err, "gitrevision")
+
+	_, err = simulate.RenderYaml(s.event, "apiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  name: build\n")
+	s.ErrorContains(err, "unexpected kind Task of build")
+}
+
+func TestSuiteTestSimulate(t *testing.T) {
+	suite.Run(t, new(SuiteTestSimulate))
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hongyuxuan/tekton-sdk-go/simulate"
+	"github.com/stretchr/testify/suite"
+)
+
+const sinkYaml = `apiVersion: triggers.tekton.dev/v1beta1
+kind: EventListener
+metadata:
+  name: github
+  namespace: ci
+  uid: 5c2a6f0e-1d4b-4f6a-9a51-8f3b1e2d7c90
+spec:
+  triggers:
+  - name: push
+    interceptors:
+    - ref:
+        name: github
+      params:
+      - name: secretRef
+        value:
+          secretName: github-secret
+          secretKey: token
+      - name: eventTypes
+        value: ["push"]
+    - ref:
+        name: cel
+      params:
+      - name: overlays
+        value:
+        - key: branch
+          expression: body.ref.split('/')[2]
+    bindings:
+    - ref: testtriggerbinding
+    - name: branch
+      value: $(extensions.branch)
+    template:
+      ref: testtriggertemplate
+  - triggerRef: tagged
+---
+apiVersion: triggers.tekton.dev/v1beta1
+kind: Trigger
+metadata:
+  name: tagged
+spec:
+  interceptors:
+  - ref:
+      name: cel
+    params:
+    - name: filter
+      value: body.ref.startsWith('refs/tags/')
+  bindings:
+  - ref: testtriggerbinding
+  template:
+    ref: testtriggertemplate
+---
+apiVersion: triggers.tekton.dev/v1beta1
+kind: TriggerBinding
+metadata:
+  name: testtriggerbinding
+spec:
+  params:
+  - name: gitrevision
+    value: $(body.head_commit.id)
+---
+apiVersion: triggers.tekton.dev/v1beta1
+kind: TriggerTemplate
+metadata:
+  name: testtriggertemplate
+spec:
+  params:
+  - name: gitrevision
+  - name: branch
+    default: none
+  resourcetemplates:
+  - apiVersion: tekton.dev/v1
+    kind: PipelineRun
+    metadata:
+      generateName: build-
+    spec:
+      pipelineRef:
+        name: testpipeline
+      params:
+      - name: revision
+        value: $(tt.params.gitrevision)
+      - name: branch
+        value: $(tt.params.branch)
+`
+
+type SuiteTestSink struct {
+	suite.Suite
+	sink   *simulate.Sink
+	server *httptest.Server
+}
+
+func (s *SuiteTestSink) SetupTest() {
+	s.sink = simulate.NewSink()
+	s.sink.Secrets = map[string]map[string]string{"github-secret": {"token": "secret"}}
+	s.Require().NoError(s.sink.Load(sinkYaml))
+	s.server = httptest.NewServer(s.sink)
+}
+
+func (s *SuiteTestSink) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *SuiteTestSink) post(path, event, secret string, payload []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, s.server.URL+path, bytes.NewReader(payload))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	return resp
+}
+
+func (s *SuiteTestSink) Test1Push() {
+	resp := s.post("/github", "push", "secret", []byte(`{"ref": "refs/heads/main", "head_commit": {"id": "b2e5f3a9c0d1"}}`))
+	defer resp.Body.Close()
+	s.Equal(http.StatusAccepted, resp.StatusCode)
+	var body map[string]string
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&body))
+	s.Equal("github", body["eventListener"])
+	s.Equal("ci", body["namespace"])
+	s.Equal("5c2a6f0e-1d4b-4f6a-9a51-8f3b1e2d7c90", body["eventListenerUID"])
+
+	records := s.sink.Records()
+	s.Require().Len(records, 1)
+	s.Equal(body["eventID"], records[0].EventID)
+	s.Require().Len(records[0].Triggers, 2)
+	s.Equal("push", records[0].Triggers[0].Trigger)
+	s.Equal("tagged", records[0].Triggers[1].Trigger)
+	s.False(records[0].Triggers[1].Intercept.Match)
+
+	resources := s.sink.Resources()
+	s.Require().Len(resources, 1)
+	pr := resources[0]
+	s.Equal("ci", pr.GetNamespace())
+	s.Equal("github", pr.GetLabels()["triggers.tekton.dev/eventlistener"])
+	s.Equal("push", pr.GetLabels()["triggers.tekton.dev/trigger"])
+	s.Equal(body["eventID"], pr.GetLabels()["triggers.tekton.dev/triggers-eventid"])
+	params := pr.Object["spec"].(map[string]interface{})["params"].([]interface{})
+	s.Equal("b2e5f3a9c0d1", params[0].(map[string]interface{})["value"])
+	s.Equal("main", params[1].(map[string]interface{})["value"])
+}
+
+func (s *SuiteTestSink) Test2Rejected() {
+	resp := s.post("/", "push", "wrong", []byte(`{"ref": "refs/heads/main"}`))
+	resp.Body.Close()
+	s.Equal(http.StatusAccepted, resp.StatusCode)
+	resp = s.post("/", "pull_request", "secret", []byte(`{"ref": "refs/heads/main"}`))
+	resp.Body.Close()
+
+	records := s.sink.Records()
+	s.Require().Len(records, 2)
+	s.False(records[0].Triggers[0].Intercept.Match)
+	s.Equal("github", records[0].Triggers[0].Intercept.StoppedBy)
+	s.Contains(records[1].Triggers[0].Intercept.Message, "event type pull_request is not allowed")
+	s.Empty(s.sink.Resources())
+}
+
+func (s *SuiteTestSink) Test3Tagged() {
+	resp := s.post("/github", "push", "secret", []byte(`{"ref": "refs/tags/v1.0.0", "head_commit": {"id": "c0ffee"}}`))
+	resp.Body.Close()
+	resources := s.sink.Resources()
+	s.Require().Len(resources, 2)
+	s.Equal("tagged", resources[1].GetLabels()["triggers.tekton.dev/trigger"])
+
+	s.sink.Reset()
+	s.Empty(s.sink.Records())
+	resp = s.post("/unknown", "push", "secret", []byte(`{}`))
+	resp.Body.Close()
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSuiteTestSink(t *testing.T) {
+	suite.Run(t, new(SuiteTestSink))
+}
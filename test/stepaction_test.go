@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hongyuxuan/tekton-sdk-go/service/v1beta1"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SuiteTestStepAction struct {
+	suite.Suite
+	server    *fakeAPIServer
+	namespace string
+}
+
+func (s *SuiteTestStepAction) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.namespace = "default"
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/tasks", tektonv1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: s.namespace},
+		Spec: tektonv1.TaskSpec{Steps: []tektonv1.Step{
+			{Name: "clone", Ref: &tektonv1.Ref{Name: "git-clone"}},
+			{Name: "build", Image: "golang", Script: "go build ./..."},
+			{Name: "clone-docs", Ref: &tektonv1.Ref{Name: "git-clone"}},
+		}},
+	})
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/tasks", tektonv1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: s.namespace},
+		Spec: tektonv1.TaskSpec{Steps: []tektonv1.Step{
+			{Name: "clone", Ref: &tektonv1.Ref{ResolverRef: tektonv1.ResolverRef{Resolver: "git", Params: tektonv1.Params{
+				{Name: "pathInRepo", Value: *tektonv1.NewStructuredValues("stepaction/git-clone/git-clone.yaml")},
+			}}}},
+		}},
+	})
+	// Tasks listed before build, which is on the second page
+	for i := 0; i < 600; i++ {
+		s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/tasks", tektonv1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("a-%03d", i), Namespace: s.namespace},
+			Spec:       tektonv1.TaskSpec{Steps: []tektonv1.Step{{Name: "echo", Image: "alpine"}}},
+		})
+	}
+}
+
+func (s *SuiteTestStepAction) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestStepAction) Test1CreateObject() {
+	res, err := s.server.client().StepAction(s.namespace).CreateObject(context.TODO(), &pipelinev1beta1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-clone", Labels: map[string]string{"app": "teststepaction"}},
+		Spec: pipelinev1beta1.StepActionSpec{
+			Image:  "alpine/git",
+			Script: "git clone $(params.url) .",
+			Params: tektonv1.ParamSpecs{{Name: "url", Type: tektonv1.ParamTypeString}},
+		},
+	})
+	s.Require().NoError(err)
+	s.Equal("tekton.dev/v1beta1", res.APIVersion)
+	s.Equal("StepAction", res.Kind)
+	s.Len(s.server.requestsMatching("POST", "/apis/tekton.dev/v1beta1/namespaces/default/stepactions"), 1)
+}
+
+func (s *SuiteTestStepAction) Test2List() {
+	res, err := s.server.client().StepAction(s.namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "app=teststepaction"})
+	s.Require().NoError(err)
+	if s.Len(res, 1) {
+		s.Equal("alpine/git", res[0].Spec.Image)
+	}
+}
+
+func (s *SuiteTestStepAction) Test3ReferencedBy() {
+	res, err := s.server.client().StepAction(s.namespace).ReferencedBy(context.TODO(), "git-clone")
+	s.Require().NoError(err)
+	s.Equal([]v1beta1.StepActionReference{{Task: "build", Steps: []string{"clone", "clone-docs"}}}, res)
+}
+
+func (s *SuiteTestStepAction) Test4Validate() {
+	errs := s.server.client().StepAction(s.namespace).Validate(context.TODO(), &pipelinev1beta1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec:       pipelinev1beta1.StepActionSpec{Script: "echo"},
+	})
+	s.ErrorContains(errs, "spec.Image")
+}
+
+func (s *SuiteTestStepAction) Test5Delete() {
+	s.Require().NoError(s.server.client().StepAction(s.namespace).Delete(context.TODO(), "git-clone"))
+	_, err := s.server.client().StepAction(s.namespace).Get(context.TODO(), "git-clone")
+	s.Error(err)
+}
+
+func TestSuiteTestStepAction(t *testing.T) {
+	suite.Run(t, new(SuiteTestStepAction))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tekton "github.com/hongyuxuan/tekton-sdk-go"
+	"github.com/hongyuxuan/tekton-sdk-go/core/option"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SuiteTestTaskRun struct {
+	suite.Suite
+	client    *tekton.Client
+	name      string
+	namespace string
+}
+
+func (s *SuiteTestTaskRun) SetupSuite() {
+	s.client = tekton.NewClient(
+		option.WithKubeconfig("./kubeconfig"),
+		option.WithSecretPrefix("default-token"),
+		// option.WithDebug(true),
+	)
+	s.name = "testtaskrun"
+	s.namespace = "default"
+}
+
+func (s *SuiteTestTaskRun) Test1CreateTaskRun() {
+	yamlStr := `apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  annotations:
+    fiops/author: hongyuxuan
+  labels:
+    app: testtaskrun
+  name: testtaskrun
+  namespace: default
+spec:
+  params:
+  - name: message
+    value: hello
+  taskSpec:
+    params:
+    - name: message
+      type: string
+    steps:
+    - name: echo
+      image: alpine
+      script: echo $(params.message)
+  serviceAccountName: default
+  timeout: 10m0s
+`
+	err := s.client.TaskRun(s.namespace).Create(context.TODO(), yamlStr)
+	s.Nil(err)
+}
+
+func (s *SuiteTestTaskRun) Test2ListTaskRun() {
+	res, err := s.client.TaskRun(s.namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "app=testtaskrun",
+		Limit:         3,
+	})
+	s.Nil(err)
+	if s.NotNil(res) {
+		found := false
+		for _, item := range res {
+			if item.Name == s.name {
+				found = true
+				break
+			}
+		}
+		s.Equal(true, found)
+	}
+}
+
+func (s *SuiteTestTaskRun) Test3GetTaskRun() {
+	res, err := s.client.TaskRun(s.namespace).Get(context.TODO(), s.name)
+	s.Nil(err)
+	if s.NotNil(res) {
+		fmt.Println(res)
+	}
+}
+
+func (s *SuiteTestTaskRun) Test4GetYamlTaskRun() {
+	res, err := s.client.TaskRun(s.namespace).GetYaml(context.TODO(), s.name)
+	s.Nil(err)
+	if s.NotEmpty(res) {
+		fmt.Println(res)
+	}
+}
+
+func (s *SuiteTestTaskRun) Test5DeleteTaskRun() {
+	err := s.client.TaskRun(s.namespace).Delete(context.TODO(), s.name)
+	s.Nil(err)
+}
+
+func TestSuiteTestTaskRun(t *testing.T) {
+	suite.Run(t, new(SuiteTestTaskRun))
+}
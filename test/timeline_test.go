@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type SuiteTestTimeline struct {
+	suite.Suite
+	server    *fakeAPIServer
+	name      string
+	namespace string
+	start     time.Time
+}
+
+func (s *SuiteTestTimeline) at(d time.Duration) *metav1.Time {
+	t := metav1.NewTime(s.start.Add(d))
+	return &t
+}
+
+func (s *SuiteTestTimeline) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.name = "testpipelinerun"
+	s.namespace = "default"
+	s.start = time.Date(2024, 9, 4, 2, 0, 0, 0, time.UTC)
+
+	ref := func(name string) *tektonv1.TaskRef { return &tektonv1.TaskRef{Name: name} }
+	pr := tektonv1.PipelineRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: s.name},
+	}
+	pr.Status.StartTime = s.at(0)
+	pr.Status.CompletionTime = s.at(250 * time.Second)
+	pr.Status.PipelineSpec = &tektonv1.PipelineSpec{
+		Tasks: []tektonv1.PipelineTask{
+			{Name: "git-clone", TaskRef: ref("git-clone")},
+			{Name: "lint", TaskRef: ref("golangci-lint"), RunAfter: []string{"git-clone"}},
+			{Name: "build", TaskRef: ref("go1.19-build"), RunAfter: []string{"git-clone"}},
+			{Name: "deploy", TaskRef: ref("k8s-deploy"), RunAfter: []string{"lint", "build"}},
+			{Name: "rollback", TaskRef: ref("k8s-rollback"), RunAfter: []string{"deploy"}},
+		},
+		Finally: []tektonv1.PipelineTask{{Name: "notify", TaskRef: ref("send-to-webhook")}},
+	}
+	pr.Status.SkippedTasks = []tektonv1.SkippedTask{{
+		Name:            "rollback",
+		Reason:          tektonv1.WhenExpressionsSkip,
+		WhenExpressions: []tektonv1.WhenExpression{{Input: "$(tasks.deploy.status)", Operator: selection.In, Values: []string{"Failed"}}},
+	}}
+	s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/pipelineruns", pr)
+
+	for _, task := range []struct {
+		name, pipelineTask string
+		start, end         time.Duration
+	}{
+		{"git-clone", "git-clone", 0, 60 * time.Second},
+		// lint is matrixed, its first TaskRun completes last and gates deploy
+		{"lint-0", "lint", 60 * time.Second, 190 * time.Second},
+		{"lint-1", "lint", 60 * time.Second, 90 * time.Second},
+		{"build", "build", 60 * time.Second, 180 * time.Second},
+		{"deploy", "deploy", 190 * time.Second, 240 * time.Second},
+		{"notify", "notify", 240 * time.Second, 250 * time.Second},
+	} {
+		tr := tektonv1.TaskRun{
+			TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+			ObjectMeta: metav1.ObjectMeta{Name: s.name + "-" + task.name, Labels: map[string]string{
+				"tekton.dev/pipelineRun":  s.name,
+				"tekton.dev/pipelineTask": task.pipelineTask,
+			}},
+		}
+		tr.Status.StartTime = s.at(task.start)
+		tr.Status.CompletionTime = s.at(task.end)
+		tr.Status.PodName = tr.Name + "-pod"
+		tr.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"}}
+		tr.Status.Steps = []tektonv1.StepState{{
+			Name:      "run",
+			Container: "step-run",
+			ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				StartedAt:  *s.at(task.start + 5*time.Second),
+				FinishedAt: *s.at(task.end),
+				Reason:     "Completed",
+			}},
+		}}
+		s.server.addJSON("/apis/tekton.dev/v1/namespaces/default/taskruns", tr)
+		s.server.addJSON("/api/v1/namespaces/default/pods", corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: tr.Status.PodName, Labels: map[string]string{
+				"tekton.dev/pipelineRun": s.name,
+			}},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: *s.at(task.start + 2*time.Second)},
+			}},
+		})
+	}
+}
+
+func (s *SuiteTestTimeline) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestTimeline) Test1Timeline() {
+	tl, err := s.server.client().PipelineRun(s.namespace).Timeline(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.Equal(250*time.Second, tl.Duration)
+	if s.Len(tl.Tasks, 6) {
+		s.Equal("git-clone", tl.Tasks[0].PipelineTask)
+		s.Equal("Succeeded", tl.Tasks[0].Status)
+		s.Equal(60*time.Second, tl.Tasks[0].Duration)
+		s.Equal(2*time.Second, tl.Tasks[0].QueueDuration)
+		s.Equal(55*time.Second, tl.Tasks[0].Steps[0].Duration)
+		s.Equal("notify", tl.Tasks[5].PipelineTask)
+		s.True(tl.Tasks[5].Finally)
+	}
+	s.Equal([]string{"git-clone", "lint", "deploy", "notify"}, tl.CriticalPath)
+	s.Equal(250*time.Second, tl.CriticalPathDuration)
+	if s.Len(tl.Skipped, 1) {
+		s.Equal("rollback", tl.Skipped[0].Name)
+		s.Equal(string(tektonv1.WhenExpressionsSkip), tl.Skipped[0].Reason)
+	}
+}
+
+func TestSuiteTestTimeline(t *testing.T) {
+	suite.Run(t, new(SuiteTestTimeline))
+}
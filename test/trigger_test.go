@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+type SuiteTestTrigger struct {
+	suite.Suite
+	server    *fakeAPIServer
+	name      string
+	namespace string
+}
+
+func (s *SuiteTestTrigger) SetupSuite() {
+	s.server = newFakeAPIServer()
+	s.name = "testtrigger"
+	s.namespace = "default"
+	s.server.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/default/triggerbindings", tektonv1beta1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "testtriggerbinding"},
+		Spec: tektonv1beta1.TriggerBindingSpec{Params: []tektonv1beta1.Param{
+			{Name: "gitrevision", Value: "$(body.ref)"},
+		}},
+	})
+	s.server.addJSON("/apis/triggers.tekton.dev/v1beta1/clustertriggerbindings", tektonv1beta1.ClusterTriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "github"},
+		Spec: tektonv1beta1.TriggerBindingSpec{Params: []tektonv1beta1.Param{
+			{Name: "gitrepositoryurl", Value: "$(body.repository.clone_url)"},
+		}},
+	})
+	s.server.addJSON("/apis/triggers.tekton.dev/v1beta1/namespaces/default/triggertemplates", tektonv1beta1.TriggerTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "testtriggertemplate"},
+		Spec: tektonv1beta1.TriggerTemplateSpec{Params: []tektonv1beta1.ParamSpec{
+			{Name: "gitrevision"}, {Name: "gitrepositoryurl"}, {Name: "namespace"},
+		}},
+	})
+	url, _ := apis.ParseURL("http://cel-interceptor.tekton-pipelines.svc:8443/cel")
+	s.server.addJSON("/apis/triggers.tekton.dev/v1alpha1/clusterinterceptors", v1alpha1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{Name: "cel"},
+		Spec:       v1alpha1.ClusterInterceptorSpec{ClientConfig: v1alpha1.ClientConfig{URL: url}},
+	})
+}
+
+func (s *SuiteTestTrigger) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *SuiteTestTrigger) Test1CreateTrigger() {
+	namespace := "default"
+	template := "testtriggertemplate"
+	trigger := &tektonv1beta1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Name: s.name, Labels: map[string]string{"app": s.name}},
+		Spec: tektonv1beta1.TriggerSpec{
+			Bindings: []*tektonv1beta1.TriggerSpecBinding{
+				{Ref: "testtriggerbinding"},
+				{Ref: "github", Kind: tektonv1beta1.ClusterTriggerBindingKind},
+				{Name: "namespace", Value: &namespace},
+			},
+			Template: tektonv1beta1.TriggerSpecTemplate{Ref: &template},
+			Interceptors: []*tektonv1beta1.TriggerInterceptor{{
+				Ref:    tektonv1beta1.InterceptorRef{Name: "cel"},
+				Params: []tektonv1beta1.InterceptorParams{{Name: "filter", Value: apiextensionsv1.JSON{Raw: []byte(`"body.ref == 'refs/heads/main'"`)}}},
+			}},
+		},
+	}
+	s.Nil(s.server.client().Trigger(s.namespace).Validate(context.TODO(), trigger))
+	res, err := s.server.client().Trigger(s.namespace).CreateObject(context.TODO(), trigger)
+	s.Require().NoError(err)
+	s.Equal("Trigger", res.Kind)
+	s.NotEmpty(res.UID)
+}
+
+func (s *SuiteTestTrigger) Test2ListTrigger() {
+	res, err := s.server.client().Trigger(s.namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: "app=" + s.name})
+	s.Require().NoError(err)
+	s.Len(res, 1)
+}
+
+func (s *SuiteTestTrigger) Test3ExpandTrigger() {
+	res, err := s.server.client().Trigger(s.namespace).Expand(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.Equal("testtriggertemplate", res.TemplateRef)
+	s.Len(res.Template.Params, 3)
+	if s.Len(res.Bindings, 3) {
+		s.Equal(tektonv1beta1.NamespacedTriggerBindingKind, res.Bindings[0].Kind)
+		s.Equal(tektonv1beta1.ClusterTriggerBindingKind, res.Bindings[1].Kind)
+		s.Empty(res.Bindings[2].Ref)
+	}
+	s.Equal([]tektonv1beta1.Param{
+		{Name: "gitrevision", Value: "$(body.ref)"},
+		{Name: "gitrepositoryurl", Value: "$(body.repository.clone_url)"},
+		{Name: "namespace", Value: "default"},
+	}, res.Params)
+	if s.Len(res.Interceptors, 1) {
+		s.Equal("cel", res.Interceptors[0].Name)
+		s.Equal(tektonv1beta1.ClusterInterceptorKind, res.Interceptors[0].Kind)
+		s.Equal("http://cel-interceptor.tekton-pipelines.svc:8443/cel", res.Interceptors[0].URL.String())
+	}
+}
+
+func (s *SuiteTestTrigger) Test4GetYamlTrigger() {
+	res, err := s.server.client().Trigger(s.namespace).GetYaml(context.TODO(), s.name)
+	s.Require().NoError(err)
+	s.Contains(res, "kind: Trigger\n")
+}
+
+func (s *SuiteTestTrigger) Test5DeleteTrigger() {
+	s.Nil(s.server.client().Trigger(s.namespace).Delete(context.TODO(), s.name))
+	_, err := s.server.client().Trigger(s.namespace).Expand(context.TODO(), s.name)
+	s.NotNil(err)
+}
+
+func TestSuiteTestTrigger(t *testing.T) {
+	suite.Run(t, new(SuiteTestTrigger))
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hongyuxuan/tekton-sdk-go/validation"
+	"github.com/stretchr/testify/suite"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SuiteTestValidation struct {
+	suite.Suite
+}
+
+func (s *SuiteTestValidation) Test1ValidPipeline() {
+	err := validation.Validate(context.TODO(), &tektonv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "testpipeline"},
+		Spec: tektonv1.PipelineSpec{Tasks: []tektonv1.PipelineTask{
+			{Name: "git-clone", TaskRef: &tektonv1.TaskRef{Name: "git-clone"}},
+		}},
+	})
+	s.Nil(err)
+}
+
+func (s *SuiteTestValidation) Test2FieldPaths() {
+	err := validation.Validate(context.TODO(), &tektonv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "testpipeline"},
+		Spec: tektonv1.PipelineSpec{Tasks: []tektonv1.PipelineTask{
+			{Name: "Build_Image", TaskRef: &tektonv1.TaskRef{Name: "build"}},
+		}},
+	})
+	if s.NotNil(err) {
+		s.Contains(err.Error(), "spec.tasks[0].name")
+	}
+}
+
+func (s *SuiteTestValidation) Test3TriggerTypes() {
+	s.Nil(validation.Validate(context.TODO(), &tektonv1beta1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "testtriggerbinding"},
+		Spec:       tektonv1beta1.TriggerBindingSpec{Params: []tektonv1beta1.Param{{Name: "revision", Value: "$(body.ref)"}}},
+	}))
+	err := validation.Validate(context.TODO(), &tektonv1beta1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{Name: "testeventlistener"},
+		Spec: tektonv1beta1.EventListenerSpec{Triggers: []tektonv1beta1.EventListenerTrigger{
+			{Name: "notemplate", Bindings: []*tektonv1beta1.TriggerSpecBinding{{Ref: "testtriggerbinding"}}},
+		}},
+	})
+	if s.NotNil(err) {
+		s.Contains(err.Error(), "spec.triggers[0]")
+	}
+}
+
+func (s *SuiteTestValidation) Test4ValidateYaml() {
+	yamlStr := `apiVersion: tekton.dev/v1
+kind: Task
+metadata:
+  name: testtask
+spec:
+  steps:
+  - name: echo
+    image: alpine
+    script: echo $(params.message)
+---
+apiVersion: triggers.tekton.dev/v1beta1
+kind: TriggerTemplate
+metadata:
+  name: testtriggertemplate
+spec:
+  params:
+  - name: revision
+`
+	err := validation.ValidateYaml(context.TODO(), yamlStr)
+	if s.NotNil(err) {
+		s.Contains(err.Error(), "Task/testtask.spec.steps[0].script")
+		s.Contains(err.Error(), "TriggerTemplate/testtriggertemplate.spec.resourcetemplates")
+	}
+
+	s.ErrorContains(validation.ValidateYaml(context.TODO(), "apiVersion: v1\nkind: ConfigMap\n"), "unsupported kind v1/ConfigMap")
+}
+
+func TestSuiteTestValidation(t *testing.T) {
+	suite.Run(t, new(SuiteTestValidation))
+}
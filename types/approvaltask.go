@@ -0,0 +1,99 @@
+package types
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalTask is the openshift-pipelines.org/v1alpha1 ApprovalTask of the manual approval gate: the
+// controller creates one for each CustomRun of the ApprovalTask custom task, with the same name,
+// and approvers answer it by setting their input in spec.approvers.
+type ApprovalTask struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApprovalTaskSpec   `json:"spec,omitempty"`
+	Status ApprovalTaskStatus `json:"status,omitempty"`
+}
+
+type ApprovalTaskSpec struct {
+	Approvers                 []ApproverDetails `json:"approvers"`
+	NumberOfApprovalsRequired int               `json:"numberOfApprovalsRequired"`
+	Description               string            `json:"description,omitempty"`
+}
+
+// ApproverDetails is a user, or a group whose members answer in Users.
+type ApproverDetails struct {
+	Name string `json:"name"`
+	// Input is ApprovalInputPending, ApprovalInputApprove or ApprovalInputReject
+	Input   string `json:"input"`
+	Message string `json:"message,omitempty"`
+	// Type is ApproverTypeUser or ApproverTypeGroup
+	Type  string        `json:"type,omitempty"`
+	Users []UserDetails `json:"users,omitempty"`
+}
+
+type UserDetails struct {
+	Name  string `json:"name"`
+	Input string `json:"input"`
+}
+
+type ApprovalTaskStatus struct {
+	// Approvers are the users who approved
+	Approvers         []string        `json:"approvers,omitempty"`
+	ApproversResponse []ApproverState `json:"approversResponse,omitempty"`
+	// State is ApprovalStatePending, ApprovalStateApproved or ApprovalStateRejected
+	State     string       `json:"state,omitempty"`
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+type ApproverState struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+	// Response is ApprovalStateApproved or ApprovalStateRejected
+	Response     string             `json:"response"`
+	Message      string             `json:"message,omitempty"`
+	GroupMembers []GroupMemberState `json:"groupMembers,omitempty"`
+}
+
+type GroupMemberState struct {
+	Name     string `json:"name"`
+	Response string `json:"response"`
+	Message  string `json:"message,omitempty"`
+}
+
+const (
+	ApprovalInputPending = "pending"
+	ApprovalInputApprove = "approve"
+	ApprovalInputReject  = "reject"
+
+	ApprovalStatePending  = "pending"
+	ApprovalStateApproved = "approved"
+	ApprovalStateRejected = "rejected"
+
+	ApproverTypeUser  = "User"
+	ApproverTypeGroup = "Group"
+)
+
+// DeepCopy returns a copy of the ApprovalTask sharing no slices or maps with it.
+func (in *ApprovalTask) DeepCopy() *ApprovalTask {
+	if in == nil {
+		return nil
+	}
+	out := &ApprovalTask{TypeMeta: in.TypeMeta, Spec: in.Spec, Status: in.Status}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Approvers = make([]ApproverDetails, len(in.Spec.Approvers))
+	for i, a := range in.Spec.Approvers {
+		a.Users = append([]UserDetails(nil), a.Users...)
+		out.Spec.Approvers[i] = a
+	}
+	out.Status.Approvers = append([]string(nil), in.Status.Approvers...)
+	out.Status.ApproversResponse = make([]ApproverState, len(in.Status.ApproversResponse))
+	for i, r := range in.Status.ApproversResponse {
+		r.GroupMembers = append([]GroupMemberState(nil), r.GroupMembers...)
+		out.Status.ApproversResponse[i] = r
+	}
+	if in.Status.StartTime != nil {
+		out.Status.StartTime = in.Status.StartTime.DeepCopy()
+	}
+	return out
+}
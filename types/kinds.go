@@ -0,0 +1,50 @@
+package types
+
+import "fmt"
+
+// ResourceKind describes where the API server serves a Tekton kind.
+type ResourceKind struct {
+	Kind     string
+	Group    string
+	Version  string
+	Resource string
+}
+
+func (k ResourceKind) APIVersion() string {
+	return k.Group + "/" + k.Version
+}
+
+// Path is the collection path of the kind in namespace, e.g. /apis/tekton.dev/v1/namespaces/default/pipelines.
+func (k ResourceKind) Path(namespace string) string {
+	return fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s", k.Group, k.Version, namespace, k.Resource)
+}
+
+// Kinds are the namespaced kinds known to the SDK, ordered so that an object comes after the
+// objects it references: StepActions before Tasks before Pipelines, bindings, templates and interceptors before Triggers and EventListeners.
+var Kinds = []ResourceKind{
+	{Kind: "StepAction", Group: "tekton.dev", Version: "v1beta1", Resource: "stepactions"},
+	{Kind: "Task", Group: "tekton.dev", Version: "v1", Resource: "tasks"},
+	{Kind: "Pipeline", Group: "tekton.dev", Version: "v1", Resource: "pipelines"},
+	{Kind: "TriggerBinding", Group: "triggers.tekton.dev", Version: "v1beta1", Resource: "triggerbindings"},
+	{Kind: "TriggerTemplate", Group: "triggers.tekton.dev", Version: "v1beta1", Resource: "triggertemplates"},
+	{Kind: "Interceptor", Group: "triggers.tekton.dev", Version: "v1alpha1", Resource: "interceptors"},
+	{Kind: "Trigger", Group: "triggers.tekton.dev", Version: "v1beta1", Resource: "triggers"},
+	{Kind: "EventListener", Group: "triggers.tekton.dev", Version: "v1beta1", Resource: "eventlisteners"},
+	{Kind: "PipelineRun", Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"},
+	{Kind: "TaskRun", Group: "tekton.dev", Version: "v1", Resource: "taskruns"},
+	{Kind: "CustomRun", Group: "tekton.dev", Version: "v1beta1", Resource: "customruns"},
+	{Kind: "ResolutionRequest", Group: "resolution.tekton.dev", Version: "v1beta1", Resource: "resolutionrequests"},
+}
+
+// ConfigKinds are the kinds that make up the configuration of a namespace, as opposed to its runs.
+var ConfigKinds = []string{"StepAction", "Task", "Pipeline", "TriggerBinding", "TriggerTemplate", "Interceptor", "Trigger", "EventListener"}
+
+// LookupKind returns the ResourceKind of kind and its position in Kinds.
+func LookupKind(kind string) (ResourceKind, int, bool) {
+	for i, k := range Kinds {
+		if k.Kind == kind {
+			return k, i, true
+		}
+	}
+	return ResourceKind{}, -1, false
+}
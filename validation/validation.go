@@ -0,0 +1,118 @@
+// Package validation runs the defaulting and validation logic of the Tekton Pipelines and
+// Triggers admission webhooks locally, so manifests can be checked without a cluster.
+package validation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hongyuxuan/tekton-sdk-go/core/errorx"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/contexts"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	tektonv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	uyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"knative.dev/pkg/apis"
+)
+
+// Object is a Tekton resource the webhooks know how to default and validate,
+// e.g. *tektonv1.Pipeline or *tektonv1beta1.EventListener.
+type Object interface {
+	runtime.Object
+	apis.Defaultable
+	apis.Validatable
+}
+
+// Validate applies SetDefaults to a copy of obj and validates it the way the admission webhook
+// does on create. The returned error carries field paths such as spec.tasks[0].name.
+func Validate(ctx context.Context, obj Object) *apis.FieldError {
+	ctx = apis.WithinCreate(contexts.WithUpgradeViaDefaulting(ctx))
+	defaulted := obj.DeepCopyObject().(Object)
+	defaulted.SetDefaults(ctx)
+	return defaulted.Validate(ctx)
+}
+
+// ValidateYaml validates every document of a (multi-document) YAML or JSON manifest. Errors are
+// prefixed with the kind and name of the offending document, e.g. Pipeline/build.spec.tasks.
+func ValidateYaml(ctx context.Context, yamlStr string) error {
+	d := uyaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(yamlStr), 4096)
+	var errs *apis.FieldError
+	for {
+		var raw runtime.RawExtension
+		err := d.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errorx.NewDefaultError("decode is err: %v", err.Error())
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		obj, err := Decode(raw.Raw)
+		if err != nil {
+			return err
+		}
+		meta := obj.(interface{ GetName() string })
+		errs = errs.Also(Validate(ctx, obj).ViaField(fmt.Sprintf("%s/%s", obj.GetObjectKind().GroupVersionKind().Kind, meta.GetName())))
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// Decode decodes a single JSON or YAML document into its typed Tekton object.
+func Decode(data []byte) (Object, error) {
+	var typeMeta runtime.TypeMeta
+	if err := uyaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, errorx.NewDefaultError("decode is err: %v", err.Error())
+	}
+	obj, ok := newObject(typeMeta)
+	if !ok {
+		return nil, errorx.NewDefaultError("unsupported kind %s/%s", typeMeta.APIVersion, typeMeta.Kind)
+	}
+	if err := uyaml.Unmarshal(data, obj); err != nil {
+		return nil, errorx.NewDefaultError("decode %s is err: %v", typeMeta.Kind, err.Error())
+	}
+	return obj, nil
+}
+
+func newObject(typeMeta runtime.TypeMeta) (Object, bool) {
+	switch typeMeta.APIVersion + "/" + typeMeta.Kind {
+	case "tekton.dev/v1/Task":
+		return &tektonv1.Task{}, true
+	case "tekton.dev/v1/Pipeline":
+		return &tektonv1.Pipeline{}, true
+	case "tekton.dev/v1/PipelineRun":
+		return &tektonv1.PipelineRun{}, true
+	case "tekton.dev/v1/TaskRun":
+		return &tektonv1.TaskRun{}, true
+	case "tekton.dev/v1beta1/StepAction":
+		return &pipelinev1beta1.StepAction{}, true
+	case "tekton.dev/v1beta1/CustomRun":
+		return &pipelinev1beta1.CustomRun{}, true
+	case "resolution.tekton.dev/v1beta1/ResolutionRequest":
+		return &resolutionv1beta1.ResolutionRequest{}, true
+	case "triggers.tekton.dev/v1beta1/TriggerBinding":
+		return &tektonv1beta1.TriggerBinding{}, true
+	case "triggers.tekton.dev/v1beta1/ClusterTriggerBinding":
+		return &tektonv1beta1.ClusterTriggerBinding{}, true
+	case "triggers.tekton.dev/v1beta1/TriggerTemplate":
+		return &tektonv1beta1.TriggerTemplate{}, true
+	case "triggers.tekton.dev/v1beta1/Trigger":
+		return &tektonv1beta1.Trigger{}, true
+	case "triggers.tekton.dev/v1beta1/EventListener":
+		return &tektonv1beta1.EventListener{}, true
+	case "triggers.tekton.dev/v1alpha1/ClusterInterceptor":
+		return &tektonv1alpha1.ClusterInterceptor{}, true
+	case "triggers.tekton.dev/v1alpha1/Interceptor":
+		return &tektonv1alpha1.Interceptor{}, true
+	}
+	return nil, false
+}